@@ -0,0 +1,118 @@
+package i18n
+
+import (
+	"context"
+	"log"
+	"strings"
+)
+
+// Lister backends that could list all translations
+type Lister interface {
+	LoadTranslations() []*Translation
+}
+
+// Writer backends that could save translations
+type Writer interface {
+	SaveTranslation(*Translation) error
+}
+
+// Deleter backends that could delete translations
+type Deleter interface {
+	DeleteTranslation(*Translation) error
+}
+
+// Finder backends that could find a translation by its locale and key
+type Finder interface {
+	FindTranslation(*Translation) Translation
+}
+
+// Searcher backends that could search translations of locale by keyword
+type Searcher interface {
+	SearchTranslations(locale, keyword string) []*Translation
+}
+
+// WritableChecker backends that could report whether a translation could be saved into it, e.g: Router, whose routes might be read-only
+type WritableChecker interface {
+	Writable(*Translation) bool
+}
+
+// writable return true if translation could be saved into backend, translation could be nil to check backend only
+func writable(backend Backend, translation *Translation) bool {
+	if writerOf(backend) == nil {
+		return false
+	}
+
+	if checker, ok := backend.(WritableChecker); ok && translation != nil {
+		return checker.Writable(translation)
+	}
+	return true
+}
+
+// writableBackend return first backend that translation could be saved into and its index, translation could be nil
+func (i18n *I18n) writableBackend(translation *Translation) (Backend, int) {
+	for idx, backend := range i18n.Backends {
+		if writable(backend, translation) {
+			return backend, idx
+		}
+	}
+	return nil, -1
+}
+
+// Editable return true if translation could be changed by SaveTranslation, translations owned by read-only backends that have higher priority than writable backends are not editable
+func (i18n *I18n) Editable(locale, key string) bool {
+	backend, idx := i18n.writableBackend(&Translation{Locale: locale, Key: i18n.scopedKey(key), Context: i18n.msgctxt})
+	if backend == nil {
+		return false
+	}
+
+	if translation, ok := i18n.snapshot.get(locale, MessageKey(i18n.msgctxt, i18n.scopedKey(key))); ok && translation.BackendIndex > 0 {
+		return idx < translation.BackendIndex
+	}
+	return true
+}
+
+// SearchTranslations search translations of locale by keyword from all backends, backends that implement Searcher will search translations by themselves
+func (i18n *I18n) SearchTranslations(locale, keyword string) (translations []*Translation) {
+	var (
+		results = map[string]*Translation{}
+		keys    []string
+	)
+
+	keyword = strings.ToLower(keyword)
+	for i := len(i18n.Backends) - 1; i >= 0; i-- {
+		var (
+			backend = i18n.Backends[i]
+			matched []*Translation
+		)
+
+		if searcher, ok := backend.(Searcher); ok {
+			matched = searcher.SearchTranslations(locale, keyword)
+		} else {
+			// backends that failed to load translations are skipped, so one failed backend won't break searching
+			loaded, err := loadTranslations(context.Background(), backend)
+			if err != nil {
+				log.Printf("i18n: failed to search translations, got %v", err)
+			}
+
+			for _, translation := range loaded {
+				if translation.Locale == locale && (strings.Contains(strings.ToLower(translation.Key), keyword) || strings.Contains(strings.ToLower(translation.Value), keyword)) {
+					matched = append(matched, translation)
+				}
+			}
+		}
+
+		for _, translation := range matched {
+			translation = translation.copy()
+			translation.Backend = backend
+			if _, ok := results[translation.messageKey()]; !ok {
+				keys = append(keys, translation.messageKey())
+			}
+			results[translation.messageKey()] = translation
+		}
+	}
+
+	for _, key := range keys {
+		translations = append(translations, results[key])
+	}
+	return translations
+}
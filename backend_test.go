@@ -0,0 +1,63 @@
+package i18n
+
+import "testing"
+
+type readOnlyBackend struct {
+	translations []*Translation
+}
+
+func (b *readOnlyBackend) LoadTranslations() []*Translation {
+	return b.translations
+}
+
+func TestBackendCapabilities(t *testing.T) {
+	var (
+		files = &readOnlyBackend{translations: []*Translation{{Key: "hello", Locale: "en-US", Value: "Hello"}, {Key: "title", Locale: "en-US", Value: "Title"}}}
+		db    = &memoryBackend{translations: map[string]*Translation{}}
+	)
+
+	db.SaveTranslation(&Translation{Key: "title", Locale: "en-US", Value: "DB Title"})
+	i18n := New(files, db)
+
+	if err := i18n.SaveTranslation(&Translation{Key: "bye", Locale: "en-US", Value: "Bye"}); err != nil {
+		t.Errorf("should save translation into writable backend, but got %v", err)
+	}
+
+	if _, ok := db.translations["en-US/bye"]; !ok {
+		t.Errorf("translation should be saved into writable backend")
+	}
+
+	if i18n.Editable("en-US", "hello") || i18n.Editable("en-US", "title") {
+		t.Errorf("translations owned by read-only backend with higher priority shouldn't be editable")
+	}
+
+	if !i18n.Editable("en-US", "bye") || !i18n.Editable("en-US", "missing") || !i18n.Editable("zh-CN", "hello") {
+		t.Errorf("translations should be editable if they are not owned by read-only backends")
+	}
+
+	i18n.DeleteTranslation(&Translation{Key: "title", Locale: "en-US"})
+	if _, ok := db.translations["en-US/title"]; ok || len(files.translations) != 2 {
+		t.Errorf("should delete translation from deletable backends only")
+	}
+
+	readOnly := New(files)
+	if err := readOnly.SaveTranslation(&Translation{Key: "bye", Locale: "en-US", Value: "Bye"}); err == nil {
+		t.Errorf("should return error if there is no writable backend")
+	}
+
+	if readOnly.Editable("en-US", "bye") {
+		t.Errorf("translations shouldn't be editable if there is no writable backend")
+	}
+}
+
+func TestSearchTranslations(t *testing.T) {
+	files := &readOnlyBackend{translations: []*Translation{{Key: "hello", Locale: "en-US", Value: "Hello"}, {Key: "title", Locale: "en-US", Value: "Title"}}}
+	db := &memoryBackend{translations: map[string]*Translation{}}
+	db.SaveTranslation(&Translation{Key: "hello", Locale: "en-US", Value: "Hello from DB"})
+	db.SaveTranslation(&Translation{Key: "hello", Locale: "zh-CN", Value: "你好"})
+
+	results := New(files, db).SearchTranslations("en-US", "HELLO")
+	if len(results) != 1 || results[0].Value != "Hello" || results[0].Backend != files {
+		t.Errorf("should search translations with backend priority, but got %v", results)
+	}
+}
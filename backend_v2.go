@@ -0,0 +1,188 @@
+package i18n
+
+import (
+	"context"
+	"errors"
+	"fmt"
+)
+
+// BackendV2 translation backend that supports context and returns errors, use it with I18n by wrapping it with FromBackendV2
+// backends could implement optional capabilities: WriterV2, DeleterV2, FinderV2, Watcher
+type BackendV2 interface {
+	LoadTranslations(ctx context.Context) ([]*Translation, error)
+}
+
+// WriterV2 backends that could save translations
+type WriterV2 interface {
+	SaveTranslation(ctx context.Context, translation *Translation) error
+}
+
+// DeleterV2 backends that could delete translations
+type DeleterV2 interface {
+	DeleteTranslation(ctx context.Context, translation *Translation) error
+}
+
+// FinderV2 backends that could find a translation by its locale and key, return nil if not found
+type FinderV2 interface {
+	FindTranslation(ctx context.Context, translation *Translation) (*Translation, error)
+}
+
+// FromBackendV2 adapt BackendV2 to Backend, so it could be used with I18n, e.g:
+//
+//	I18n := i18n.New(i18n.FromBackendV2(backend), yaml.New("config/locales"))
+func FromBackendV2(backend BackendV2) Backend {
+	return &backendV2Adapter{backend: backend}
+}
+
+type backendV2Adapter struct {
+	backend BackendV2
+}
+
+// LoadTranslations load translations with background context, errors are ignored
+func (adapter *backendV2Adapter) LoadTranslations() []*Translation {
+	translations, _ := adapter.backend.LoadTranslations(context.Background())
+	return translations
+}
+
+// Watch watch changes if the adapted backend is a Watcher
+func (adapter *backendV2Adapter) Watch(onChange func()) func() {
+	if watcher, ok := adapter.backend.(Watcher); ok {
+		return watcher.Watch(onChange)
+	}
+	return func() {}
+}
+
+// loadTranslations load translations from backend, panics of Backend are returned as errors
+func loadTranslations(ctx context.Context, backend Backend) (translations []*Translation, err error) {
+	if adapter, ok := backend.(*backendV2Adapter); ok {
+		return adapter.backend.LoadTranslations(ctx)
+	}
+
+	if loader, ok := backend.(contextLoader); ok {
+		return loader.loadContext(ctx, nil)
+	}
+
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("failed to load translations: %v", r)
+		}
+	}()
+	return backend.LoadTranslations(), nil
+}
+
+// writerOf return save function of backend, return nil if backend is not writable
+func writerOf(backend Backend) func(context.Context, *Translation) error {
+	if adapter, ok := backend.(*backendV2Adapter); ok {
+		if writer, ok := adapter.backend.(WriterV2); ok {
+			return writer.SaveTranslation
+		}
+		return nil
+	}
+
+	if writer, ok := backend.(Writer); ok {
+		return func(ctx context.Context, translation *Translation) error {
+			if err := ctx.Err(); err != nil {
+				return err
+			}
+			return writer.SaveTranslation(translation)
+		}
+	}
+	return nil
+}
+
+// deleterOf return delete function of backend, return nil if backend is not deletable
+func deleterOf(backend Backend) func(context.Context, *Translation) error {
+	if adapter, ok := backend.(*backendV2Adapter); ok {
+		if deleter, ok := adapter.backend.(DeleterV2); ok {
+			return deleter.DeleteTranslation
+		}
+		return nil
+	}
+
+	if deleter, ok := backend.(Deleter); ok {
+		return func(ctx context.Context, translation *Translation) error {
+			if err := ctx.Err(); err != nil {
+				return err
+			}
+			return deleter.DeleteTranslation(translation)
+		}
+	}
+	return nil
+}
+
+// findTranslation find translation from backend, translations will be loaded if backend is not a finder
+func findTranslation(ctx context.Context, backend Backend, translation *Translation) (Translation, bool, error) {
+	if adapter, ok := backend.(*backendV2Adapter); ok {
+		if finder, ok := adapter.backend.(FinderV2); ok {
+			result, err := finder.FindTranslation(ctx, translation)
+			if err != nil || result == nil {
+				return Translation{}, false, err
+			}
+			return *result, result.Value != "", nil
+		}
+	} else if finder, ok := backend.(Finder); ok {
+		result := finder.FindTranslation(translation)
+		return result, result.Value != "", nil
+	}
+
+	translations, err := loadTranslations(ctx, backend)
+	for _, t := range translations {
+		if t.Locale == translation.Locale && t.Key == translation.Key && t.Context == translation.Context {
+			return *t, t.Value != "", err
+		}
+	}
+	return Translation{}, false, err
+}
+
+// isFinder whether backend could find a translation without loading all of its translations
+func isFinder(backend Backend) bool {
+	if adapter, ok := backend.(*backendV2Adapter); ok {
+		_, ok = adapter.backend.(FinderV2)
+		return ok
+	}
+	_, ok := backend.(Finder)
+	return ok
+}
+
+// NewWithError initialize I18n with backends, return errors of loading translations
+func NewWithError(backends ...Backend) (*I18n, error) {
+	i18n := newI18n(backends...)
+	return i18n, i18n.Reload()
+}
+
+// SaveTranslationContext save translation into first writable backend with context
+func (i18n *I18n) SaveTranslationContext(ctx context.Context, translation *Translation) error {
+	var err = errors.New("failed to save translation")
+	for _, backend := range i18n.Backends {
+		if save := writerOf(backend); save != nil && writable(backend, translation) {
+			if err = save(ctx, translation); err == nil {
+				translation.Backend = backend
+				i18n.AddTranslation(translation)
+				i18n.publish(ChangeEvent{Locale: translation.Locale, Key: translation.Key, Context: translation.Context})
+				return nil
+			}
+		}
+	}
+	return err
+}
+
+// DeleteTranslationContext delete translation from all deletable backends with context
+func (i18n *I18n) DeleteTranslationContext(ctx context.Context, translation *Translation) (err error) {
+	for _, backend := range i18n.Backends {
+		if del := deleterOf(backend); del != nil {
+			if e := del(ctx, translation); e != nil {
+				err = e
+			}
+		}
+	}
+
+	i18n.publish(ChangeEvent{Locale: translation.Locale, Key: translation.Key, Context: translation.Context, Deleted: true})
+	if e := i18n.removeTranslation(translation); err == nil {
+		err = e
+	}
+	return err
+}
@@ -0,0 +1,81 @@
+package i18n
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+type backendV2 struct {
+	translations map[string]*Translation
+	loadErr      error
+}
+
+func (b *backendV2) LoadTranslations(ctx context.Context) (translations []*Translation, err error) {
+	for _, translation := range b.translations {
+		translations = append(translations, translation)
+	}
+	return translations, b.loadErr
+}
+
+func (b *backendV2) SaveTranslation(ctx context.Context, t *Translation) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	b.translations[cacheKey(t.Locale, t.Key)] = &Translation{Key: t.Key, Locale: t.Locale, Value: t.Value}
+	return nil
+}
+
+type panicBackend struct{}
+
+func (panicBackend) LoadTranslations() []*Translation {
+	panic("invalid translations")
+}
+
+func TestBackendV2(t *testing.T) {
+	b := &backendV2{translations: map[string]*Translation{"en-US/hello": {Key: "hello", Locale: "en-US", Value: "Hello"}}}
+	i18n, err := NewWithError(FromBackendV2(b), &readOnlyBackend{})
+	if err != nil {
+		t.Fatalf("failed to initialize i18n, got %v", err)
+	}
+
+	if value := i18n.T("en-US", "hello"); value != "Hello" {
+		t.Errorf("should load translations from BackendV2, but got %v", value)
+	}
+
+	if err := i18n.SaveTranslation(&Translation{Key: "bye", Locale: "en-US", Value: "Bye"}); err != nil || b.translations["en-US/bye"] == nil {
+		t.Errorf("should save translation into writable BackendV2, got %v", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if err := i18n.SaveTranslationContext(ctx, &Translation{Key: "canceled", Locale: "en-US", Value: "Canceled"}); err != context.Canceled {
+		t.Errorf("should return error of context, but got %v", err)
+	}
+
+	if !i18n.Editable("en-US", "hello") {
+		t.Errorf("translations of writable BackendV2 should be editable")
+	}
+
+	if err := i18n.DeleteTranslation(&Translation{Key: "bye", Locale: "en-US"}); err != nil || b.translations["en-US/bye"] == nil {
+		t.Errorf("shouldn't delete translation from BackendV2 that isn't deletable, got %v", err)
+	}
+
+	b.loadErr = errors.New("connection refused")
+	b.translations = map[string]*Translation{}
+	if err := i18n.Reload(); err == nil {
+		t.Errorf("should return error of loading translations")
+	}
+
+	if value := i18n.T("en-US", "hello"); value != "Hello" {
+		t.Errorf("translations shouldn't be changed if failed to reload, but got %v", value)
+	}
+
+	if _, err := NewWithError(panicBackend{}); err == nil {
+		t.Errorf("panics of backends should be returned as errors")
+	}
+
+	if New(panicBackend{}) == nil {
+		t.Errorf("New should log errors of loading translations")
+	}
+}
@@ -0,0 +1,186 @@
+package database
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/jinzhu/gorm"
+	"github.com/qor/i18n"
+)
+
+// batchSize max rows of one statement when saving translations in batches
+const batchSize = 100
+
+// SaveTranslations save translations into DB backend in a transaction, translations are upserted in batches with `ON CONFLICT` (PostgreSQL, SQLite) or `ON DUPLICATE KEY UPDATE` (MySQL),
+// other dialects, or tables without the unique index of locale, context & key, save them one by one, changes are recorded as versions
+func (backend *Backend) SaveTranslations(translations []*i18n.Translation) error {
+	return backend.DB.Transaction(func(tx *gorm.DB) error {
+		return saveTranslations(tx, translations)
+	})
+}
+
+func saveTranslations(tx *gorm.DB, translations []*i18n.Translation) error {
+	upsert := upsertClause(tx)
+	if upsert == "" {
+		for _, t := range translations {
+			if err := saveTranslation(tx, t); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	existing, err := existingValues(tx, translations)
+	if err != nil {
+		return err
+	}
+
+	// rows could only be upserted once in a statement, the last translation wins
+	var (
+		latest  = map[string]*i18n.Translation{}
+		changed []*i18n.Translation
+	)
+
+	for _, t := range translations {
+		latest[translationID(t.Locale, t.Context, t.Key)] = t
+	}
+
+	for _, t := range translations {
+		id := translationID(t.Locale, t.Context, t.Key)
+		if latest[id] != t {
+			continue
+		}
+
+		if value, ok := existing[id]; !ok || value != t.Value {
+			changed = append(changed, t)
+		}
+	}
+
+	var (
+		quote = tx.Dialect().Quote
+		now   = gorm.NowFunc()
+	)
+
+	for start := 0; start < len(changed); start += batchSize {
+		var (
+			end          = start + batchSize
+			placeholders []string
+			values       []interface{}
+			versions     []interface{}
+		)
+
+		if end > len(changed) {
+			end = len(changed)
+		}
+
+		for _, t := range changed[start:end] {
+			var updatedBy, source = "", i18n.OriginAPI
+			if t.Meta != nil {
+				updatedBy = t.Meta.UpdatedBy
+				if t.Meta.Origin != "" {
+					source = t.Meta.Origin
+				}
+			}
+
+			placeholders = append(placeholders, "(?, ?, ?, ?, ?, ?)")
+			values = append(values, t.Locale, t.Key, t.Context, t.Value, now, updatedBy)
+			oldValue, found := existing[translationID(t.Locale, t.Context, t.Key)]
+			versions = append(versions, t.Locale, t.Key, t.Context, oldValue, t.Value, !found, false, updatedBy, source, now)
+		}
+
+		if err := tx.Exec(fmt.Sprintf("INSERT INTO %v (%v) VALUES %v %v",
+			tx.NewScope(&Translation{}).QuotedTableName(),
+			quoteColumns(quote, "locale", "key", "context", "value", "updated_at", "updated_by"),
+			strings.Join(placeholders, ", "), upsert,
+		), values...).Error; err != nil {
+			return err
+		}
+
+		if err := tx.Exec(fmt.Sprintf("INSERT INTO %v (%v) VALUES %v",
+			tx.NewScope(&TranslationVersion{}).QuotedTableName(),
+			quoteColumns(quote, "locale", "key", "context", "old_value", "new_value", "created", "deleted", "actor", "source", "created_at"),
+			strings.Repeat(", (?, ?, ?, ?, ?, ?, ?, ?, ?, ?)", end-start)[2:],
+		), versions...).Error; err != nil {
+			return err
+		}
+	}
+
+	for _, t := range translations {
+		if err := saveMeta(tx, t); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// upsertClause return upsert clause of dialect, return blank string if dialect is not supported or translations have no unique index, e.g: MySQL couldn't index LONGTEXT key
+func upsertClause(tx *gorm.DB) string {
+	quote := tx.Dialect().Quote
+	if !tx.Dialect().HasIndex(tx.NewScope(&Translation{}).TableName(), uniqueIndex) {
+		return ""
+	}
+
+	switch tx.Dialect().GetName() {
+	case "postgres", "sqlite3":
+		return fmt.Sprintf("ON CONFLICT (%v) DO UPDATE SET %v = excluded.%v, %v = excluded.%v, %v = excluded.%v",
+			quoteColumns(quote, "locale", "context", "key"),
+			quote("value"), quote("value"), quote("updated_at"), quote("updated_at"), quote("updated_by"), quote("updated_by"))
+	case "mysql":
+		return fmt.Sprintf("ON DUPLICATE KEY UPDATE %v = VALUES(%v), %v = VALUES(%v), %v = VALUES(%v)",
+			quote("value"), quote("value"), quote("updated_at"), quote("updated_at"), quote("updated_by"), quote("updated_by"))
+	}
+	return ""
+}
+
+// existingValues return values of saved translations, indexed by translationID
+func existingValues(tx *gorm.DB, translations []*i18n.Translation) (map[string]string, error) {
+	var (
+		results = map[string]string{}
+		quote   = tx.Dialect().Quote
+		locales []string
+		keys    []string
+		seen    = map[string]bool{}
+	)
+
+	for _, t := range translations {
+		if !seen["locale:"+t.Locale] {
+			seen["locale:"+t.Locale] = true
+			locales = append(locales, t.Locale)
+		}
+		if !seen["key:"+t.Key] {
+			seen["key:"+t.Key] = true
+			keys = append(keys, t.Key)
+		}
+	}
+
+	for start := 0; start < len(keys); start += batchSize {
+		var (
+			end     = start + batchSize
+			records []Translation
+		)
+
+		if end > len(keys) {
+			end = len(keys)
+		}
+
+		if err := tx.Where(fmt.Sprintf("%v IN (?) AND %v IN (?)", quote("locale"), quote("key")), locales, keys[start:end]).Find(&records).Error; err != nil {
+			return nil, err
+		}
+
+		for _, record := range records {
+			results[translationID(record.Locale, record.Context, record.Key)] = record.Value
+		}
+	}
+	return results, nil
+}
+
+func translationID(locale, context, key string) string {
+	return locale + "\x00" + i18n.MessageKey(context, key)
+}
+
+func quoteColumns(quote func(string) string, columns ...string) string {
+	for idx, column := range columns {
+		columns[idx] = quote(column)
+	}
+	return strings.Join(columns, ", ")
+}
@@ -2,52 +2,146 @@ package database
 
 import (
 	"fmt"
+	"log"
+	"strings"
+	"time"
 
 	"github.com/jinzhu/gorm"
 	"github.com/qor/i18n"
 )
 
+var (
+	_ i18n.Writer       = &Backend{}
+	_ i18n.Deleter      = &Backend{}
+	_ i18n.Finder       = &Backend{}
+	_ i18n.Searcher     = &Backend{}
+	_ i18n.LocaleLoader = &Backend{}
+	_ i18n.LocaleLister = &Backend{}
+	_ i18n.BatchWriter  = &Backend{}
+)
+
 // Translation is a struct used to save translations into databae
 type Translation struct {
 	Locale string `sql:"size:12;"`
 	Key    string `sql:"size:4294967295;"`
 	Value  string `sql:"size:4294967295"`
+	// Context message context of translation, translations with same key could have different contexts
+	Context string `sql:"not null;default:''"`
+	// UpdatedAt, UpdatedBy when and who updated the translation
+	UpdatedAt time.Time
+	UpdatedBy string
 }
 
+// uniqueIndex unique index of translations' locale, context & key, it is required to upsert translations in batches
+const uniqueIndex = "idx_translations_key_with_locale_and_context"
+
 // New new DB backend for I18n
-func New(db *gorm.DB) i18n.Backend {
-	db.AutoMigrate(&Translation{})
-	if err := db.Model(&Translation{}).AddUniqueIndex("idx_translations_key_with_locale", "locale", "key").Error; err != nil {
-		fmt.Printf("Failed to create unique index for translations key & locale, got: %v\n", err.Error())
+func New(db *gorm.DB) *Backend {
+	db.AutoMigrate(&Translation{}, &TranslationMeta{}, &TranslationVersion{})
+	// unique index of locale & key is replaced by unique index of locale, context & key
+	if db.Dialect().HasIndex("translations", "idx_translations_key_with_locale") {
+		db.Model(&Translation{}).RemoveIndex("idx_translations_key_with_locale")
+	}
+	// MySQL couldn't index LONGTEXT key, translations are saved one by one without the unique index
+	if err := db.Model(&Translation{}).AddUniqueIndex(uniqueIndex, "locale", "context", "key").Error; err != nil {
+		fmt.Printf("Failed to create unique index for translations key, locale & context, got: %v\n", err.Error())
+	}
+	if err := db.Model(&TranslationMeta{}).AddUniqueIndex("idx_translation_meta_key_with_context", "context", "key").Error; err != nil {
+		fmt.Printf("Failed to create unique index for translation meta key & context, got: %v\n", err.Error())
 	}
 	return &Backend{DB: db}
 }
 
+// conditions conditions to find translation by its locale, key and context, zero values are included
+func conditions(t *i18n.Translation) map[string]interface{} {
+	return map[string]interface{}{"locale": t.Locale, "key": t.Key, "context": t.Context}
+}
+
 // Backend DB backend
 type Backend struct {
 	DB *gorm.DB
 }
 
-// LoadTranslations load translations from DB backend
-func (backend *Backend) LoadTranslations() (translations []*i18n.Translation) {
-	backend.DB.Find(&translations)
+// LoadTranslations load translations from DB backend, errors are logged, use BackendV2 to handle them
+func (backend *Backend) LoadTranslations() []*i18n.Translation {
+	translations, err := loadTranslations(backend.DB, "")
+	logError(err)
 	return translations
 }
 
-// SaveTranslation save translation into DB backend
+// LoadLocale load translations of locale from DB backend, errors are logged, use BackendV2 to handle them
+func (backend *Backend) LoadLocale(locale string) []*i18n.Translation {
+	translations, err := loadTranslations(backend.DB, column(backend.DB, "locale")+" = ?", locale)
+	logError(err)
+	return translations
+}
+
+// Locales list locales of translations in DB backend, errors are logged, use BackendV2 to handle them
+func (backend *Backend) Locales() []string {
+	locales, err := loadLocales(backend.DB)
+	logError(err)
+	return locales
+}
+
+func logError(err error) {
+	if err != nil {
+		log.Printf("i18n: %v", err)
+	}
+}
+
+func loadLocales(db *gorm.DB) (locales []string, err error) {
+	err = db.Model(&Translation{}).Pluck("DISTINCT locale", &locales).Error
+	return locales, err
+}
+
+// SaveTranslation save translation into DB backend, the change is recorded as a version
 func (backend *Backend) SaveTranslation(t *i18n.Translation) error {
-	return backend.DB.Where(Translation{Key: t.Key, Locale: t.Locale}).
-		Assign(Translation{Value: t.Value}).
-		FirstOrCreate(&Translation{}).Error
+	return backend.DB.Transaction(func(tx *gorm.DB) error {
+		return saveTranslation(tx, t)
+	})
 }
 
 // FindTranslation find translation from DB backend
 func (backend *Backend) FindTranslation(t *i18n.Translation) (translation i18n.Translation) {
-	backend.DB.Where(Translation{Key: t.Key, Locale: t.Locale}).Find(&translation)
+	where, args := matchTranslation(backend.DB, t)
+	translations, err := loadTranslations(backend.DB, where, args...)
+	logError(err)
+	if len(translations) > 0 {
+		translation = *translations[0]
+	}
 	return translation
 }
 
-// DeleteTranslation delete translation into DB backend
+// SearchTranslations search translations of locale by keyword from DB backend, `%` and `_` in keyword are matched literally
+func (backend *Backend) SearchTranslations(locale, keyword string) []*i18n.Translation {
+	var (
+		pattern = "%" + escapeLike(strings.ToLower(keyword)) + "%"
+		escape  = likeEscapeClause(backend.DB)
+	)
+
+	translations, err := loadTranslations(backend.DB, fmt.Sprintf("%v = ? AND (LOWER(%v) LIKE ? %v OR LOWER(%v) LIKE ? %v)", column(backend.DB, "locale"), column(backend.DB, "key"), escape, column(backend.DB, "value"), escape), locale, pattern, pattern)
+	logError(err)
+	return translations
+}
+
+// likeEscaper escape wildcards of LIKE with backslash
+var likeEscaper = strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`)
+
+func escapeLike(value string) string {
+	return likeEscaper.Replace(value)
+}
+
+// likeEscapeClause return ESCAPE clause that uses backslash as escape character, backslash is escaped in string literals of MySQL
+func likeEscapeClause(db *gorm.DB) string {
+	if db.Dialect().GetName() == "mysql" {
+		return `ESCAPE '\\'`
+	}
+	return `ESCAPE '\'`
+}
+
+// DeleteTranslation delete translation into DB backend, the change is recorded as a version
 func (backend *Backend) DeleteTranslation(t *i18n.Translation) error {
-	return backend.DB.Where(Translation{Key: t.Key, Locale: t.Locale}).Delete(&Translation{}).Error
+	return backend.DB.Transaction(func(tx *gorm.DB) error {
+		return deleteTranslation(tx, t)
+	})
 }
@@ -1,7 +1,12 @@
 package database_test
 
 import (
+	"context"
+	"fmt"
+	"reflect"
+	"sync"
 	"testing"
+	"time"
 
 	"github.com/jinzhu/gorm"
 	"github.com/qor/i18n"
@@ -10,11 +15,11 @@ import (
 )
 
 var db *gorm.DB
-var backend i18n.Backend
+var backend *database.Backend
 
 func init() {
 	db = utils.TestDB()
-	db.DropTable(&database.Translation{})
+	db.DropTable(&database.Translation{}, &database.TranslationMeta{}, &database.TranslationVersion{})
 	backend = database.New(db)
 }
 
@@ -26,6 +31,10 @@ func TestTranslations(t *testing.T) {
 		t.Errorf("should has only one translation")
 	}
 
+	if locales := backend.Locales(); len(locales) != 1 || locales[0] != "zh-CN" {
+		t.Errorf("should list locales of translations, but got %v", locales)
+	}
+
 	backend.DeleteTranslation(&translation)
 	if len(backend.LoadTranslations()) != 0 {
 		t.Errorf("should has none translation")
@@ -45,3 +54,412 @@ func TestTranslations(t *testing.T) {
 		t.Errorf("should has one translation left")
 	}
 }
+
+func TestNotifier(t *testing.T) {
+	db.DropTable(&database.TranslationChange{})
+	notifier := database.NewNotifier(db, 10*time.Millisecond)
+
+	node1, node2 := i18n.New(backend), i18n.New(backend)
+	node1.UseNotifier(notifier)
+	if err := node2.UseNotifier(notifier); err != nil {
+		t.Fatal(err)
+	}
+
+	node1.SaveTranslation(&i18n.Translation{Key: "notifier", Locale: "en-US", Value: "Notified"})
+	for i := 0; i < 100; i++ {
+		if _, ok := node2.Lookup("en-US", "notifier"); ok {
+			break
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+
+	if result, ok := node2.Lookup("en-US", "notifier"); !ok || result.Value != "Notified" {
+		t.Errorf("should update translation of other nodes, but got %v", result.Value)
+	}
+
+	node1.DeleteTranslation(&i18n.Translation{Key: "notifier", Locale: "en-US"})
+	for i := 0; i < 100; i++ {
+		if _, ok := node2.Lookup("en-US", "notifier"); !ok {
+			break
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+
+	if _, ok := node2.Lookup("en-US", "notifier"); ok {
+		t.Errorf("should delete translation of other nodes")
+	}
+}
+
+func TestNotifierOverlap(t *testing.T) {
+	db.DropTable(&database.TranslationChange{})
+	notifier := database.NewNotifier(db, 10*time.Millisecond)
+
+	var (
+		mutex sync.Mutex
+		keys  []string
+	)
+
+	stop, err := notifier.Subscribe(func(event i18n.ChangeEvent) {
+		mutex.Lock()
+		keys = append(keys, event.Key)
+		mutex.Unlock()
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer stop()
+
+	notifier.Publish(i18n.ChangeEvent{Locale: "en-US", Key: "first"})
+	time.Sleep(50 * time.Millisecond)
+
+	// event of a transaction that committed after later events had been polled
+	db.Create(&database.TranslationChange{Locale: "en-US", Key: "late", CreatedAt: time.Now().Add(-time.Second)})
+	time.Sleep(50 * time.Millisecond)
+
+	mutex.Lock()
+	defer mutex.Unlock()
+	if fmt.Sprint(keys) != "[first late]" {
+		t.Errorf("should poll late committed events once, but got %v", keys)
+	}
+}
+
+func TestNotifierError(t *testing.T) {
+	table := db.Table("translation_changes_errors")
+	notifier := database.NewNotifier(table, 10*time.Millisecond)
+	errs := make(chan error, 100)
+	notifier.OnError = func(err error) {
+		select {
+		case errs <- err:
+		default:
+		}
+	}
+
+	stop, err := notifier.Subscribe(func(i18n.ChangeEvent) {})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer stop()
+
+	db.DropTable("translation_changes_errors")
+
+	select {
+	case <-errs:
+	case <-time.After(time.Second):
+		t.Errorf("should report errors of polling")
+	}
+}
+
+func TestSearchTranslations(t *testing.T) {
+	backend.SaveTranslation(&i18n.Translation{Key: "search.hello", Value: "Hello World", Locale: "en-US"})
+	backend.SaveTranslation(&i18n.Translation{Key: "search.bye", Value: "Bye", Locale: "en-US"})
+	backend.SaveTranslation(&i18n.Translation{Key: "search.hello", Value: "Hello World", Locale: "zh-CN"})
+
+	if results := backend.SearchTranslations("en-US", "world"); len(results) != 1 || results[0].Key != "search.hello" {
+		t.Errorf("should search translations by value, but got %v", results)
+	}
+
+	if results := backend.SearchTranslations("en-US", "SEARCH."); len(results) != 2 {
+		t.Errorf("should search translations by key, but got %v", results)
+	}
+
+	backend.SaveTranslation(&i18n.Translation{Key: "search.discount", Value: `100% off_now \o/`, Locale: "en-US"})
+	for _, keyword := range []string{"%", "_", `\`, `% off_`} {
+		if results := backend.SearchTranslations("en-US", keyword); len(results) != 1 || results[0].Key != "search.discount" {
+			t.Errorf("should match %v literally, but got %v", keyword, results)
+		}
+	}
+}
+
+func TestLoadError(t *testing.T) {
+	db.DropTableIfExists("translations_missing")
+	missing := &database.Backend{DB: db.Table("translations_missing")}
+	if translations := missing.LoadTranslations(); len(translations) != 0 {
+		t.Errorf("DB backend should log errors of querying DB, but got %v", translations)
+	}
+
+	if I18n := i18n.New(missing); I18n == nil {
+		t.Errorf("New should log errors of querying DB")
+	}
+
+	missingV2 := i18n.FromBackendV2(&database.BackendV2{DB: db.Table("translations_missing")})
+	if _, err := i18n.NewWithError(missingV2); err == nil {
+		t.Errorf("should return error of querying DB when initializing")
+	}
+
+	I18n := i18n.New(backend)
+	I18n.Backends = []i18n.Backend{missingV2}
+	if err := I18n.ReloadContext(context.Background()); err == nil {
+		t.Errorf("should return error of querying DB when reloading")
+	}
+
+	I18n = i18n.NewLazy(nil, backend)
+	I18n.Backends = []i18n.Backend{missingV2}
+	if err := I18n.LoadLocales(context.Background(), "en-US"); err == nil {
+		t.Errorf("should return error of querying DB when loading locale")
+	}
+}
+
+func TestBackendV2(t *testing.T) {
+	backendV2 := database.NewV2(db)
+	I18n, err := i18n.NewWithError(i18n.FromBackendV2(backendV2))
+	if err != nil {
+		t.Fatalf("failed to load translations, got %v", err)
+	}
+
+	if err := I18n.SaveTranslation(&i18n.Translation{Key: "v2.hello", Locale: "en-US", Value: "Hello V2"}); err != nil {
+		t.Errorf("failed to save translation, got %v", err)
+	}
+
+	if translation, err := backendV2.FindTranslation(context.Background(), &i18n.Translation{Key: "v2.hello", Locale: "en-US"}); err != nil || translation == nil || translation.Value != "Hello V2" {
+		t.Errorf("failed to find translation, got %v, %v", translation, err)
+	}
+
+	if translation, err := backendV2.FindTranslation(context.Background(), &i18n.Translation{Key: "v2.missing", Locale: "en-US"}); err != nil || translation != nil {
+		t.Errorf("should return nil for missing translation, got %v, %v", translation, err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if err := I18n.ReloadContext(ctx); err == nil {
+		t.Errorf("should return error if context is canceled")
+	}
+
+	if value := I18n.T("en-US", "v2.hello"); value != "Hello V2" {
+		t.Errorf("translations shouldn't be changed if failed to reload, but got %v", value)
+	}
+
+	if err := I18n.DeleteTranslation(&i18n.Translation{Key: "v2.hello", Locale: "en-US"}); err != nil {
+		t.Errorf("failed to delete translation, got %v", err)
+	}
+}
+
+func TestLoadLocale(t *testing.T) {
+	backend.SaveTranslation(&i18n.Translation{Key: "locale.hello", Value: "Hello", Locale: "en-GB"})
+	backend.SaveTranslation(&i18n.Translation{Key: "locale.hello", Value: "Bonjour", Locale: "fr-FR"})
+
+	if translations := backend.LoadLocale("fr-FR"); len(translations) != 1 || translations[0].Value != "Bonjour" {
+		t.Errorf("should only load translations of locale, but got %v", translations)
+	}
+
+	I18n := i18n.NewLazy(nil, backend)
+	if value := I18n.T("en-GB", "locale.hello"); value != "Hello" {
+		t.Errorf("should load locale lazily, but got %v", value)
+	}
+}
+
+func TestMeta(t *testing.T) {
+	backend.SaveTranslation(&i18n.Translation{Key: "meta.hello", Value: "Hello", Locale: "en-US", Meta: &i18n.Meta{
+		Description: "Greeting on home page",
+		MaxLength:   20,
+		Tags:        []string{"home", "greeting"},
+		UpdatedBy:   "jinzhu",
+	}})
+	backend.SaveTranslation(&i18n.Translation{Key: "meta.hello", Value: "你好", Locale: "zh-CN"})
+
+	translation := backend.FindTranslation(&i18n.Translation{Key: "meta.hello", Locale: "en-US"})
+	if meta := translation.Meta; meta == nil || meta.Description != "Greeting on home page" || meta.MaxLength != 20 || len(meta.Tags) != 2 || meta.UpdatedBy != "jinzhu" || meta.UpdatedAt.IsZero() {
+		t.Errorf("should save metadata of translation, but got %#v", meta)
+	}
+
+	translation = backend.FindTranslation(&i18n.Translation{Key: "meta.hello", Locale: "zh-CN"})
+	if meta := translation.Meta; meta == nil || meta.Description != "Greeting on home page" || meta.UpdatedBy != "" {
+		t.Errorf("metadata of key should be shared by locales, but got %#v", meta)
+	}
+
+	placeholders := []string{"{count, plural, one {# item} other {# items}}", "name"}
+	backend.SaveTranslation(&i18n.Translation{Key: "meta.items", Value: "{count} items", Locale: "en-US", Meta: &i18n.Meta{Placeholders: placeholders, Tags: []string{"cart"}}})
+	translation = backend.FindTranslation(&i18n.Translation{Key: "meta.items", Locale: "en-US"})
+	if meta := translation.Meta; meta == nil || !reflect.DeepEqual(meta.Placeholders, placeholders) || !reflect.DeepEqual(meta.Tags, []string{"cart"}) {
+		t.Errorf("placeholders with commas should be saved, but got %#v", meta)
+	}
+
+	db.Model(&database.TranslationMeta{}).Where(map[string]interface{}{"key": "meta.hello"}).Update("tags", "home,greeting")
+	translation = backend.FindTranslation(&i18n.Translation{Key: "meta.hello", Locale: "en-US"})
+	if meta := translation.Meta; meta == nil || !reflect.DeepEqual(meta.Tags, []string{"home", "greeting"}) {
+		t.Errorf("comma separated tags saved by old versions should be read, but got %#v", meta)
+	}
+}
+
+func TestContext(t *testing.T) {
+	backend.SaveTranslation(&i18n.Translation{Key: "context.open", Value: "Open", Locale: "en-US"})
+	backend.SaveTranslation(&i18n.Translation{Key: "context.open", Value: "打开", Locale: "zh-CN", Context: "button"})
+	backend.SaveTranslation(&i18n.Translation{Key: "context.open", Value: "营业中", Locale: "zh-CN", Context: "status"})
+
+	if translation := backend.FindTranslation(&i18n.Translation{Key: "context.open", Locale: "zh-CN", Context: "status"}); translation.Value != "营业中" || translation.Context != "status" {
+		t.Errorf("should find translation by context, but got %#v", translation)
+	}
+
+	I18n := i18n.New(backend)
+	if value := I18n.TC("zh-CN", "button", "context.open"); value != "打开" {
+		t.Errorf("should translate with context button, but got %v", value)
+	}
+
+	if value := I18n.Context("status").T("zh-CN", "context.open"); value != "营业中" {
+		t.Errorf("should translate with context status, but got %v", value)
+	}
+
+	backend.DeleteTranslation(&i18n.Translation{Key: "context.open", Locale: "zh-CN", Context: "button"})
+	if translation := backend.FindTranslation(&i18n.Translation{Key: "context.open", Locale: "zh-CN", Context: "status"}); translation.Value != "营业中" {
+		t.Errorf("translations of other contexts shouldn't be deleted, but got %#v", translation)
+	}
+}
+
+func TestVersions(t *testing.T) {
+	translation := &i18n.Translation{Key: "version.hello", Locale: "ja-JP", Value: "こんにちは", Meta: &i18n.Meta{UpdatedBy: "translator", Origin: i18n.OriginAdmin}}
+	backend.SaveTranslation(translation)
+	backend.SaveTranslation(translation)
+	backend.SaveTranslation(&i18n.Translation{Key: "version.hello", Locale: "ja-JP", Value: "やあ", Meta: &i18n.Meta{Origin: i18n.OriginImport}})
+
+	versions, err := backend.TranslationVersions(translation)
+	if err != nil || len(versions) != 2 {
+		t.Fatalf("should record changed values only, but got %v, %v", versions, err)
+	}
+
+	if v := versions[0]; v.OldValue != "こんにちは" || v.NewValue != "やあ" || v.Source != i18n.OriginImport {
+		t.Errorf("should list newest version first, but got %#v", v)
+	}
+
+	if v := versions[1]; v.OldValue != "" || !v.Created || v.Actor != "translator" || v.Source != i18n.OriginAdmin {
+		t.Errorf("should record actor and source, but got %#v", v)
+	}
+
+	I18n := i18n.New(backend)
+	if err := backend.RestoreVersion(I18n, versions[1].ID, "admin"); err != nil {
+		t.Fatalf("failed to restore version, got %v", err)
+	}
+
+	if result := backend.FindTranslation(translation); result.Value != "こんにちは" {
+		t.Errorf("should restore value of version, but got %v", result.Value)
+	}
+
+	if result, _ := I18n.Lookup("ja-JP", "version.hello"); result.Value != "こんにちは" {
+		t.Errorf("should update restored translation in I18n, but got %v", result.Value)
+	}
+
+	blank := &i18n.Translation{Key: "version.blank", Locale: "ja-JP"}
+	backend.SaveTranslation(blank)
+	backend.SaveTranslation(&i18n.Translation{Key: "version.blank", Locale: "ja-JP", Value: "filled"})
+	versions, _ = backend.TranslationVersions(blank)
+	if err := backend.RestoreVersion(nil, versions[1].ID, "admin"); err != nil {
+		t.Fatalf("failed to restore version, got %v", err)
+	}
+
+	if translations := backend.LoadLocale("ja-JP"); !hasTranslation(translations, "version.blank", "") {
+		t.Errorf("blank translation should be restored instead of deleted")
+	}
+
+	backend.DeleteTranslation(translation)
+	if versions, _ := backend.TranslationVersions(translation); len(versions) != 4 || !versions[0].Deleted || versions[1].Source != i18n.OriginRestore {
+		t.Errorf("should record deleting and restoring, but got %v", versions)
+	}
+}
+
+func TestRestoreLocale(t *testing.T) {
+	backend.SaveTranslation(&i18n.Translation{Key: "restore.hello", Locale: "ko-KR", Value: "안녕하세요"})
+	backend.SaveTranslation(&i18n.Translation{Key: "restore.bye", Locale: "ko-KR", Value: "안녕히 가세요"})
+	backend.SaveTranslation(&i18n.Translation{Key: "restore.hello", Locale: "en-US", Value: "Hello"})
+	backend.SaveTranslation(&i18n.Translation{Key: "restore.blank", Locale: "ko-KR"})
+
+	at := time.Now()
+	time.Sleep(10 * time.Millisecond)
+
+	// bad import
+	backend.SaveTranslation(&i18n.Translation{Key: "restore.hello", Locale: "ko-KR", Value: "broken"})
+	backend.SaveTranslation(&i18n.Translation{Key: "restore.hello", Locale: "ko-KR", Value: "broken again"})
+	backend.DeleteTranslation(&i18n.Translation{Key: "restore.bye", Locale: "ko-KR"})
+	backend.SaveTranslation(&i18n.Translation{Key: "restore.new", Locale: "ko-KR", Value: "new"})
+	backend.SaveTranslation(&i18n.Translation{Key: "restore.hello", Locale: "en-US", Value: "Hi"})
+	backend.SaveTranslation(&i18n.Translation{Key: "restore.blank", Locale: "ko-KR", Value: "filled"})
+
+	I18n := i18n.New(backend)
+	if err := backend.RestoreLocale(I18n, "ko-KR", at, "admin"); err != nil {
+		t.Fatalf("failed to restore locale, got %v", err)
+	}
+
+	for key, value := range map[string]string{"restore.hello": "안녕하세요", "restore.bye": "안녕히 가세요", "restore.new": ""} {
+		if result := backend.FindTranslation(&i18n.Translation{Key: key, Locale: "ko-KR"}); result.Value != value {
+			t.Errorf("%v should be restored to %v, but got %v", key, value, result.Value)
+		}
+	}
+
+	if result := backend.FindTranslation(&i18n.Translation{Key: "restore.hello", Locale: "en-US"}); result.Value != "Hi" {
+		t.Errorf("translations of other locales shouldn't be restored, but got %v", result.Value)
+	}
+
+	for key, value := range map[string]string{"restore.hello": "안녕하세요", "restore.bye": "안녕히 가세요", "restore.new": ""} {
+		if result, _ := I18n.Lookup("ko-KR", key); result.Value != value {
+			t.Errorf("%v should be restored to %v in I18n, but got %v", key, value, result.Value)
+		}
+	}
+
+	if translations := backend.LoadLocale("ko-KR"); !hasTranslation(translations, "restore.blank", "") || hasTranslation(translations, "restore.new", "new") {
+		t.Errorf("blank translations should be restored, created translations should be deleted, but got %v", translations)
+	}
+}
+
+func hasTranslation(translations []*i18n.Translation, key, value string) bool {
+	for _, translation := range translations {
+		if translation.Key == key && translation.Value == value {
+			return true
+		}
+	}
+	return false
+}
+
+func TestSaveTranslations(t *testing.T) {
+	backend.SaveTranslation(&i18n.Translation{Key: "batch.hello", Value: "Hello", Locale: "en-US"})
+	backend.SaveTranslation(&i18n.Translation{Key: "batch.bye", Value: "Bye", Locale: "en-US"})
+
+	var translations []*i18n.Translation
+	for i := 0; i < 250; i++ {
+		translations = append(translations, &i18n.Translation{Key: fmt.Sprintf("batch.%v", i), Value: fmt.Sprint(i), Locale: "en-US"})
+	}
+
+	translations = append(translations,
+		&i18n.Translation{Key: "batch.hello", Value: "Hi", Locale: "en-US", Meta: &i18n.Meta{UpdatedBy: "importer", Origin: i18n.OriginImport}},
+		&i18n.Translation{Key: "batch.bye", Value: "Bye", Locale: "en-US"},
+		&i18n.Translation{Key: "batch.open", Value: "打开", Locale: "zh-CN", Context: "button"},
+		&i18n.Translation{Key: "batch.open", Value: "开", Locale: "zh-CN", Context: "button"},
+	)
+
+	if err := backend.SaveTranslations(translations); err != nil {
+		t.Fatalf("failed to save translations, got %v", err)
+	}
+
+	for key, value := range map[string]string{"batch.0": "0", "batch.249": "249", "batch.hello": "Hi", "batch.bye": "Bye"} {
+		if result := backend.FindTranslation(&i18n.Translation{Key: key, Locale: "en-US"}); result.Value != value {
+			t.Errorf("%v should be saved as %v, but got %v", key, value, result.Value)
+		}
+	}
+
+	if result := backend.FindTranslation(&i18n.Translation{Key: "batch.open", Locale: "zh-CN", Context: "button"}); result.Value != "开" {
+		t.Errorf("last translation should win, but got %v", result.Value)
+	}
+
+	if versions, _ := backend.TranslationVersions(&i18n.Translation{Key: "batch.hello", Locale: "en-US"}); len(versions) != 2 || versions[0].OldValue != "Hello" || versions[0].NewValue != "Hi" || versions[0].Actor != "importer" || versions[0].Source != i18n.OriginImport {
+		t.Errorf("should record changes as versions, but got %v", versions)
+	}
+
+	if versions, _ := backend.TranslationVersions(&i18n.Translation{Key: "batch.bye", Locale: "en-US"}); len(versions) != 1 {
+		t.Errorf("unchanged translations shouldn't be saved, but got %v", versions)
+	}
+
+	I18n := i18n.New(backend)
+	if err := I18n.SaveTranslations([]*i18n.Translation{{Key: "batch.hello", Value: "Hello again", Locale: "en-US"}}); err != nil || I18n.T("en-US", "batch.hello") != "Hello again" {
+		t.Errorf("I18n should save translations into DB backend in batches, got %v", err)
+	}
+
+	// e.g: MySQL couldn't create unique index of LONGTEXT key
+	db.Model(&database.Translation{}).RemoveIndex("idx_translations_key_with_locale_and_context")
+	defer db.Model(&database.Translation{}).AddUniqueIndex("idx_translations_key_with_locale_and_context", "locale", "context", "key")
+
+	if err := backend.SaveTranslations([]*i18n.Translation{{Key: "batch.hello", Value: "Hello without index", Locale: "en-US"}, {Key: "batch.index", Value: "Index", Locale: "en-US"}}); err != nil {
+		t.Errorf("should save translations one by one without unique index, got %v", err)
+	}
+
+	var count int
+	db.Model(&database.Translation{}).Where(map[string]interface{}{"locale": "en-US", "key": "batch.hello"}).Count(&count)
+	if result := backend.FindTranslation(&i18n.Translation{Key: "batch.hello", Locale: "en-US"}); result.Value != "Hello without index" || count != 1 {
+		t.Errorf("translations should be updated without unique index, but got %v, %v rows", result.Value, count)
+	}
+}
@@ -0,0 +1,93 @@
+package database
+
+import (
+	"context"
+
+	"github.com/jinzhu/gorm"
+	"github.com/qor/i18n"
+)
+
+var (
+	_ i18n.BackendV2      = &BackendV2{}
+	_ i18n.WriterV2       = &BackendV2{}
+	_ i18n.DeleterV2      = &BackendV2{}
+	_ i18n.FinderV2       = &BackendV2{}
+	_ i18n.LocaleLoaderV2 = &BackendV2{}
+	_ i18n.LocaleListerV2 = &BackendV2{}
+	_ i18n.BatchWriterV2  = &BackendV2{}
+)
+
+// NewV2 new DB backend that supports context and returns errors, use it with I18n by wrapping it with i18n.FromBackendV2
+func NewV2(db *gorm.DB) *BackendV2 {
+	New(db)
+	return &BackendV2{DB: db}
+}
+
+// BackendV2 DB backend that supports context and returns errors
+type BackendV2 struct {
+	DB *gorm.DB
+}
+
+// LoadTranslations load translations from DB backend
+func (backend *BackendV2) LoadTranslations(ctx context.Context) (translations []*i18n.Translation, err error) {
+	if err = ctx.Err(); err == nil {
+		translations, err = loadTranslations(backend.DB, "")
+	}
+	return translations, err
+}
+
+// LoadLocale load translations of locale from DB backend
+func (backend *BackendV2) LoadLocale(ctx context.Context, locale string) (translations []*i18n.Translation, err error) {
+	if err = ctx.Err(); err == nil {
+		translations, err = loadTranslations(backend.DB, column(backend.DB, "locale")+" = ?", locale)
+	}
+	return translations, err
+}
+
+// Locales list locales of translations in DB backend
+func (backend *BackendV2) Locales(ctx context.Context) (locales []string, err error) {
+	if err = ctx.Err(); err == nil {
+		locales, err = loadLocales(backend.DB)
+	}
+	return locales, err
+}
+
+// SaveTranslation save translation into DB backend
+func (backend *BackendV2) SaveTranslation(ctx context.Context, t *i18n.Translation) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	return (&Backend{DB: backend.DB}).SaveTranslation(t)
+}
+
+// SaveTranslations save translations into DB backend in a transaction, see Backend.SaveTranslations
+func (backend *BackendV2) SaveTranslations(ctx context.Context, translations []*i18n.Translation) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	return backend.DB.Transaction(func(tx *gorm.DB) error {
+		return saveTranslations(tx, translations)
+	})
+}
+
+// FindTranslation find translation from DB backend, return nil if not found
+func (backend *BackendV2) FindTranslation(ctx context.Context, t *i18n.Translation) (*i18n.Translation, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	where, args := matchTranslation(backend.DB, t)
+	translations, err := loadTranslations(backend.DB, where, args...)
+	if err != nil || len(translations) == 0 {
+		return nil, err
+	}
+	return translations[0], nil
+}
+
+// DeleteTranslation delete translation from DB backend
+func (backend *BackendV2) DeleteTranslation(ctx context.Context, t *i18n.Translation) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	return (&Backend{DB: backend.DB}).DeleteTranslation(t)
+}
@@ -0,0 +1,147 @@
+package database
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/jinzhu/gorm"
+	"github.com/qor/i18n"
+)
+
+// TranslationMeta is a struct used to save metadata of translation keys into database, it is shared by all locales
+type TranslationMeta struct {
+	Key         string `sql:"size:4294967295;"`
+	Context     string `sql:"not null;default:''"`
+	Description string `sql:"size:4294967295"`
+	MaxLength   int
+	// Placeholders, Tags are saved as JSON arrays, comma separated values saved by old versions could still be read
+	Placeholders string `sql:"size:4294967295"`
+	Tags         string `sql:"size:4294967295"`
+	Source       string
+}
+
+// translationWithMeta translation joined with metadata of its key
+type translationWithMeta struct {
+	Translation
+	MetaDescription  string
+	MetaMaxLength    int
+	MetaPlaceholders string
+	MetaTags         string
+	MetaSource       string
+}
+
+// loadTranslations load translations matched conditions with their metadata from DB in one query, use column to refer columns of translations in conditions
+func loadTranslations(db *gorm.DB, where string, args ...interface{}) (translations []*i18n.Translation, err error) {
+	var (
+		records []translationWithMeta
+		quote   = db.Dialect().Quote
+		meta    = db.New().NewScope(&TranslationMeta{}).QuotedTableName()
+		columns []string
+	)
+
+	for _, name := range []string{"locale", "key", "value", "context", "updated_at", "updated_by"} {
+		columns = append(columns, column(db, name))
+	}
+	for _, name := range []string{"description", "max_length", "placeholders", "tags", "source"} {
+		columns = append(columns, fmt.Sprintf("%v.%v AS %v", meta, quote(name), quote("meta_"+name)))
+	}
+
+	scope := db.Model(&Translation{}).Select(strings.Join(columns, ", ")).Joins(fmt.Sprintf("LEFT JOIN %v ON %v.%v = %v AND %v.%v = %v",
+		meta, meta, quote("key"), column(db, "key"), meta, quote("context"), column(db, "context")))
+	if where != "" {
+		scope = scope.Where(where, args...)
+	}
+
+	if err = scope.Scan(&records).Error; err != nil {
+		return nil, err
+	}
+
+	for _, record := range records {
+		translations = append(translations, record.Translation.toTranslation(TranslationMeta{
+			Description:  record.MetaDescription,
+			MaxLength:    record.MetaMaxLength,
+			Placeholders: record.MetaPlaceholders,
+			Tags:         record.MetaTags,
+			Source:       record.MetaSource,
+		}))
+	}
+	return translations, nil
+}
+
+// column return quoted column of translations table, it is used in conditions of loadTranslations
+func column(db *gorm.DB, name string) string {
+	return db.NewScope(&Translation{}).QuotedTableName() + "." + db.Dialect().Quote(name)
+}
+
+// matchTranslation conditions of loadTranslations that match translation with its locale, key and context
+func matchTranslation(db *gorm.DB, t *i18n.Translation) (string, []interface{}) {
+	return fmt.Sprintf("%v = ? AND %v = ? AND %v = ?", column(db, "locale"), column(db, "key"), column(db, "context")), []interface{}{t.Locale, t.Key, t.Context}
+}
+
+// toTranslation convert DB record to i18n translation
+func (record Translation) toTranslation(meta TranslationMeta) *i18n.Translation {
+	return &i18n.Translation{
+		Key:     record.Key,
+		Locale:  record.Locale,
+		Value:   record.Value,
+		Context: record.Context,
+		Meta: &i18n.Meta{
+			Description:  meta.Description,
+			MaxLength:    meta.MaxLength,
+			Placeholders: splitList(meta.Placeholders),
+			Tags:         splitList(meta.Tags),
+			Source:       meta.Source,
+			UpdatedAt:    record.UpdatedAt,
+			UpdatedBy:    record.UpdatedBy,
+		},
+	}
+}
+
+// saveMeta save metadata of translation key, it won't touch saved metadata if translation has no key metadata
+func saveMeta(db *gorm.DB, t *i18n.Translation) error {
+	meta := t.Meta
+	if meta == nil || (meta.Description == "" && meta.MaxLength == 0 && len(meta.Placeholders) == 0 && len(meta.Tags) == 0 && meta.Source == "") {
+		return nil
+	}
+
+	placeholders, err := joinList(meta.Placeholders)
+	if err != nil {
+		return err
+	}
+
+	tags, err := joinList(meta.Tags)
+	if err != nil {
+		return err
+	}
+
+	return db.Where(map[string]interface{}{"key": t.Key, "context": t.Context}).Assign(map[string]interface{}{
+		"description":  meta.Description,
+		"max_length":   meta.MaxLength,
+		"placeholders": placeholders,
+		"tags":         tags,
+		"source":       meta.Source,
+	}).FirstOrCreate(&TranslationMeta{}).Error
+}
+
+// joinList encode list as JSON array, so values could contain commas, e.g: ICU placeholders `{count, plural, one {# item} other {# items}}`
+func joinList(values []string) (string, error) {
+	if len(values) == 0 {
+		return "", nil
+	}
+	value, err := json.Marshal(values)
+	return string(value), err
+}
+
+// splitList decode list saved as JSON array, or comma separated values saved by old versions
+func splitList(value string) []string {
+	if value == "" {
+		return nil
+	}
+
+	var values []string
+	if strings.HasPrefix(value, "[") && json.Unmarshal([]byte(value), &values) == nil {
+		return values
+	}
+	return strings.Split(value, ",")
+}
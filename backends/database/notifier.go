@@ -0,0 +1,135 @@
+package database
+
+import (
+	"fmt"
+	"log"
+	"sync"
+	"time"
+
+	"github.com/jinzhu/gorm"
+	"github.com/qor/i18n"
+)
+
+var _ i18n.Notifier = &Notifier{}
+
+// TranslationChange is a struct used to save translation change events into database
+type TranslationChange struct {
+	ID        uint   `gorm:"primary_key"`
+	Locale    string `sql:"size:12;"`
+	Key       string `sql:"size:4294967295;"`
+	Context   string
+	Deleted   bool
+	Source    string
+	CreatedAt time.Time `sql:"index"`
+}
+
+// NewNotifier new DB notifier, it saves change events into database, and polls new events with interval, default interval is 5 seconds
+// Postgres LISTEN/NOTIFY could be used to get notified immediately by implementing i18n.Notifier with it
+func NewNotifier(db *gorm.DB, interval time.Duration) *Notifier {
+	db.AutoMigrate(&TranslationChange{})
+	if interval <= 0 {
+		interval = 5 * time.Second
+	}
+	return &Notifier{DB: db, Interval: interval, Overlap: time.Minute, Retention: 24 * time.Hour, CleanupInterval: time.Hour}
+}
+
+// Notifier DB notifier
+type Notifier struct {
+	DB       *gorm.DB
+	Interval time.Duration
+	// Overlap events created within overlap before last polling are polled again, handled events are skipped,
+	// so events of transactions that committed late (or from nodes whose clocks are behind) won't be missed
+	Overlap time.Duration
+	// Retention change events older than retention will be removed by subscribers every CleanupInterval, it should be longer than Overlap
+	Retention       time.Duration
+	CleanupInterval time.Duration
+	// OnError called when failed to poll or clean up change events, errors are logged if it is nil
+	OnError func(error)
+}
+
+// Publish save change event into database
+func (notifier *Notifier) Publish(event i18n.ChangeEvent) error {
+	return notifier.DB.Create(&TranslationChange{Locale: event.Locale, Key: event.Key, Context: event.Context, Deleted: event.Deleted, Source: event.Source}).Error
+}
+
+// Subscribe poll change events published after subscribed, events are polled with overlap and de-duplicated by their IDs
+func (notifier *Notifier) Subscribe(handler func(i18n.ChangeEvent)) (func(), error) {
+	var (
+		done     = make(chan struct{})
+		stopOnce sync.Once
+		since    = gorm.NowFunc()
+		// seen handled events in overlap window, `map[id]created_at`
+		seen     = map[uint]time.Time{}
+		existing []TranslationChange
+	)
+
+	// events published before subscribed are treated as handled
+	if err := notifier.DB.Where("created_at >= ?", since.Add(-notifier.Overlap)).Find(&existing).Error; err != nil {
+		return nil, err
+	}
+
+	for _, change := range existing {
+		seen[change.ID] = change.CreatedAt
+	}
+
+	go func() {
+		ticker := time.NewTicker(notifier.Interval)
+		defer ticker.Stop()
+
+		var cleanup <-chan time.Time
+		if notifier.Retention > 0 && notifier.CleanupInterval > 0 {
+			cleanupTicker := time.NewTicker(notifier.CleanupInterval)
+			defer cleanupTicker.Stop()
+			cleanup = cleanupTicker.C
+		}
+
+		for {
+			select {
+			case <-ticker.C:
+				var (
+					now     = gorm.NowFunc()
+					cutoff  = since.Add(-notifier.Overlap)
+					changes []TranslationChange
+				)
+
+				if err := notifier.DB.Where("created_at >= ?", cutoff).Order("id").Find(&changes).Error; err != nil {
+					notifier.handleError(fmt.Errorf("failed to poll translation changes: %v", err))
+					continue
+				}
+				since = now
+
+				for _, change := range changes {
+					if _, ok := seen[change.ID]; ok {
+						continue
+					}
+					seen[change.ID] = change.CreatedAt
+					handler(i18n.ChangeEvent{Locale: change.Locale, Key: change.Key, Context: change.Context, Deleted: change.Deleted, Source: change.Source})
+				}
+
+				for id, createdAt := range seen {
+					if createdAt.Before(cutoff) {
+						delete(seen, id)
+					}
+				}
+			case <-cleanup:
+				if err := notifier.DB.Where("created_at < ?", gorm.NowFunc().Add(-notifier.Retention)).Delete(&TranslationChange{}).Error; err != nil {
+					notifier.handleError(fmt.Errorf("failed to clean up translation changes: %v", err))
+				}
+			case <-done:
+				return
+			}
+		}
+	}()
+
+	return func() {
+		stopOnce.Do(func() { close(done) })
+	}, nil
+}
+
+func (notifier *Notifier) handleError(err error) {
+	if notifier.OnError != nil {
+		notifier.OnError(err)
+	} else {
+		log.Printf("i18n: %v", err)
+	}
+}
@@ -0,0 +1,163 @@
+package database
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/jinzhu/gorm"
+	"github.com/qor/i18n"
+)
+
+// TranslationVersion is a struct used to save changes of translations into database, every save and delete creates a version
+type TranslationVersion struct {
+	ID       uint   `gorm:"primary_key"`
+	Locale   string `sql:"size:12;"`
+	Key      string `sql:"size:4294967295;"`
+	Context  string
+	OldValue string `sql:"size:4294967295"`
+	NewValue string `sql:"size:4294967295"`
+	// Deleted translation is deleted in this version
+	Deleted bool
+	// Created translation didn't exist before this version, a blank OldValue could be a blank translation
+	Created bool
+	// Actor who changed the translation, it is `UpdatedBy` of translation's metadata
+	Actor string
+	// Source where the change came from, it is `Origin` of translation's metadata, e.g: admin, import, api, restore
+	Source    string
+	CreatedAt time.Time `sql:"index"`
+}
+
+// saveTranslation save translation and record a version if its value changed
+func saveTranslation(tx *gorm.DB, t *i18n.Translation) error {
+	var (
+		record    Translation
+		updatedBy string
+		scope     = tx.Where(conditions(t)).First(&record)
+		found     = !scope.RecordNotFound()
+	)
+
+	if found && scope.Error != nil {
+		return scope.Error
+	}
+
+	if t.Meta != nil {
+		updatedBy = t.Meta.UpdatedBy
+	}
+
+	if err := tx.Where(conditions(t)).
+		Assign(map[string]interface{}{"value": t.Value, "updated_by": updatedBy}).
+		FirstOrCreate(&Translation{}).Error; err != nil {
+		return err
+	}
+
+	if !found || record.Value != t.Value {
+		if err := recordVersion(tx, t, record.Value, t.Value, !found, false); err != nil {
+			return err
+		}
+	}
+	return saveMeta(tx, t)
+}
+
+// deleteTranslation delete translation and record a version if it exists
+func deleteTranslation(tx *gorm.DB, t *i18n.Translation) error {
+	var record Translation
+	if scope := tx.Where(conditions(t)).First(&record); scope.RecordNotFound() {
+		return nil
+	} else if scope.Error != nil {
+		return scope.Error
+	}
+
+	if err := tx.Where(conditions(t)).Delete(&Translation{}).Error; err != nil {
+		return err
+	}
+	return recordVersion(tx, t, record.Value, "", false, true)
+}
+
+func recordVersion(tx *gorm.DB, t *i18n.Translation, oldValue, newValue string, created, deleted bool) error {
+	version := TranslationVersion{Locale: t.Locale, Key: t.Key, Context: t.Context, OldValue: oldValue, NewValue: newValue, Created: created, Deleted: deleted, Source: i18n.OriginAPI}
+	if t.Meta != nil {
+		version.Actor = t.Meta.UpdatedBy
+		if t.Meta.Origin != "" {
+			version.Source = t.Meta.Origin
+		}
+	}
+	return tx.Create(&version).Error
+}
+
+// TranslationVersions return versions of translation with its locale, key and context, newest first
+func (backend *Backend) TranslationVersions(t *i18n.Translation) (versions []TranslationVersion, err error) {
+	err = backend.DB.Where(conditions(t)).Order("id desc").Find(&versions).Error
+	return versions, err
+}
+
+// RestoreVersion restore translation to the value of version, translation will be deleted if it is deleted in the version, actor is recorded in the new version
+// restored translation is updated in I18n, and other nodes are notified with its notifier, I18n could be nil if translations are not used by any I18n
+func (backend *Backend) RestoreVersion(I18n *i18n.I18n, id uint, actor string) error {
+	var restored []*i18n.Translation
+	if err := backend.DB.Transaction(func(tx *gorm.DB) error {
+		var version TranslationVersion
+		if err := tx.First(&version, id).Error; err != nil {
+			return err
+		}
+
+		translation := &i18n.Translation{Locale: version.Locale, Key: version.Key, Context: version.Context, Value: version.NewValue}
+		restored = append(restored, translation)
+		return restore(tx, translation, version.Deleted, actor)
+	}); err != nil {
+		return err
+	}
+	return refresh(I18n, restored)
+}
+
+// RestoreLocale restore translations of locale to their values at the point in time, translations created after it will be deleted, actor is recorded in new versions
+// restored translations are updated in I18n, and other nodes are notified with its notifier, I18n could be nil if translations are not used by any I18n
+func (backend *Backend) RestoreLocale(I18n *i18n.I18n, locale string, at time.Time, actor string) error {
+	var restored []*i18n.Translation
+	if err := backend.DB.Transaction(func(tx *gorm.DB) error {
+		var (
+			versions []TranslationVersion
+			seen     = map[string]bool{}
+			quote    = tx.Dialect().Quote
+		)
+
+		if err := tx.Where(fmt.Sprintf("%v = ? AND %v > ?", quote("locale"), quote("created_at")), locale, at).Order("id").Find(&versions).Error; err != nil {
+			return err
+		}
+
+		// old value of the first version after the point in time is the value at that time
+		for _, version := range versions {
+			key := i18n.MessageKey(version.Context, version.Key)
+			if seen[key] {
+				continue
+			}
+			seen[key] = true
+
+			translation := &i18n.Translation{Locale: version.Locale, Key: version.Key, Context: version.Context, Value: version.OldValue}
+			restored = append(restored, translation)
+			if err := restore(tx, translation, version.Created, actor); err != nil {
+				return err
+			}
+		}
+		return nil
+	}); err != nil {
+		return err
+	}
+	return refresh(I18n, restored)
+}
+
+// restore save translation, or delete it if it didn't exist
+func restore(tx *gorm.DB, t *i18n.Translation, deleted bool, actor string) error {
+	t.Meta = &i18n.Meta{UpdatedBy: actor, Origin: i18n.OriginRestore}
+	if deleted {
+		return deleteTranslation(tx, t)
+	}
+	return saveTranslation(tx, t)
+}
+
+// refresh update restored translations in I18n
+func refresh(I18n *i18n.I18n, translations []*i18n.Translation) error {
+	if I18n == nil {
+		return nil
+	}
+	return I18n.RefreshTranslations(translations...)
+}
@@ -0,0 +1,332 @@
+package po
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/qor/i18n"
+)
+
+var _ i18n.Backend = &Backend{}
+
+// New new gettext PO backend for I18n, locale of a file is read from its `Language` header, or its file name if the header is missing, e.g: `zh-CN.po`
+//
+// `msgctxt` is loaded as message context, `msgid` as key, `msgstr` as value, extracted comments `#.` as description and references `#:` as source of metadata,
+// plural entries (`msgid_plural`), fuzzy and untranslated entries are ignored
+func New(paths ...string) *Backend {
+	return &Backend{paths: paths}
+}
+
+// Backend gettext PO backend
+type Backend struct {
+	paths []string
+}
+
+// files find PO files from paths
+func (backend *Backend) files() (files []string) {
+	for _, p := range backend.paths {
+		if fileInfo, err := os.Stat(p); err == nil {
+			if fileInfo.IsDir() {
+				poFiles, _ := filepath.Glob(filepath.Join(p, "*.po"))
+				files = append(files, poFiles...)
+			} else if fileInfo.Mode().IsRegular() {
+				files = append(files, p)
+			}
+		}
+	}
+	return files
+}
+
+// LoadTranslations load translations from PO backend
+func (backend *Backend) LoadTranslations() (translations []*i18n.Translation) {
+	for _, file := range backend.files() {
+		content, err := ioutil.ReadFile(file)
+		if err != nil {
+			panic(err)
+		}
+
+		locale := strings.TrimSuffix(filepath.Base(file), filepath.Ext(file))
+		if results, err := backend.LoadPOContent(content, locale); err == nil {
+			translations = append(translations, results...)
+		} else {
+			panic(fmt.Errorf("%v: %v", file, err))
+		}
+	}
+	return translations
+}
+
+// entry message of PO file
+type entry struct {
+	msgctxt, msgid, msgstr string
+	comments, references   []string
+	plural, fuzzy          bool
+	hasMsgid               bool
+}
+
+// LoadPOContent load PO content, locale is used if the content has no `Language` header
+func (backend *Backend) LoadPOContent(content []byte, locale string) (translations []*i18n.Translation, err error) {
+	var (
+		entries []*entry
+		current = &entry{}
+		field   *string
+		scanner = bufio.NewScanner(bytes.NewReader(content))
+		lineNo  int
+	)
+
+	var finish = func() {
+		if current.hasMsgid {
+			entries = append(entries, current)
+		}
+		current, field = &entry{}, nil
+	}
+
+	scanner.Buffer(make([]byte, 64*1024), 10*1024*1024)
+	for scanner.Scan() {
+		lineNo++
+		line := strings.TrimSpace(scanner.Text())
+
+		// comments belong to next entry
+		if strings.HasPrefix(line, "#") && current.hasMsgid {
+			finish()
+		}
+
+		switch {
+		case line == "":
+			finish()
+		case strings.HasPrefix(line, "#."):
+			current.comments = append(current.comments, strings.TrimSpace(line[2:]))
+		case strings.HasPrefix(line, "#:"):
+			current.references = append(current.references, strings.Fields(line[2:])...)
+		case strings.HasPrefix(line, "#,"):
+			current.fuzzy = current.fuzzy || strings.Contains(line, "fuzzy")
+		case strings.HasPrefix(line, "#"):
+			// translator comments and obsolete entries
+		case strings.HasPrefix(line, `"`):
+			if field == nil {
+				return nil, fmt.Errorf("line %v: unexpected string", lineNo)
+			}
+			value, err := unquote(line)
+			if err != nil {
+				return nil, fmt.Errorf("line %v: %v", lineNo, err)
+			}
+			*field += value
+		default:
+			keyword, value := line, ""
+			if idx := strings.IndexAny(line, " \t"); idx != -1 {
+				keyword, value = line[:idx], strings.TrimSpace(line[idx:])
+			}
+
+			if (keyword == "msgctxt" || keyword == "msgid") && current.hasMsgid {
+				finish()
+			}
+
+			switch {
+			case keyword == "msgctxt":
+				field = &current.msgctxt
+			case keyword == "msgid":
+				current.hasMsgid = true
+				field = &current.msgid
+			case keyword == "msgstr":
+				field = &current.msgstr
+			case keyword == "msgid_plural" || strings.HasPrefix(keyword, "msgstr["):
+				current.plural = true
+				field = new(string)
+			default:
+				return nil, fmt.Errorf("line %v: unknown keyword %v", lineNo, keyword)
+			}
+
+			if *field, err = unquote(value); err != nil {
+				return nil, fmt.Errorf("line %v: %v", lineNo, err)
+			}
+		}
+	}
+	finish()
+
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	for _, e := range entries {
+		if e.msgid == "" && e.msgctxt == "" {
+			if language := header(e.msgstr, "Language"); language != "" {
+				locale = strings.Replace(language, "_", "-", -1)
+			}
+			continue
+		}
+
+		if e.plural || e.fuzzy || e.msgstr == "" {
+			continue
+		}
+
+		translation := &i18n.Translation{Locale: locale, Key: e.msgid, Value: e.msgstr, Context: e.msgctxt}
+		if len(e.comments) > 0 || len(e.references) > 0 {
+			translation.Meta = &i18n.Meta{Description: strings.Join(e.comments, "\n"), Source: strings.Join(e.references, " ")}
+		}
+		translations = append(translations, translation)
+	}
+
+	for _, translation := range translations {
+		translation.Locale = locale
+	}
+	return translations, nil
+}
+
+// header return value of header field
+func header(headers, name string) string {
+	for _, line := range strings.Split(headers, "\n") {
+		if idx := strings.Index(line, ":"); idx != -1 && strings.TrimSpace(line[:idx]) == name {
+			return strings.TrimSpace(line[idx+1:])
+		}
+	}
+	return ""
+}
+
+// Export write translations of locale to w in PO format, message context is written as `msgctxt`, description and source of metadata are written as comments, e.g:
+//
+//	po.Export(file, "zh-CN", I18n.LoadTranslations()["zh-CN"])
+func Export(w io.Writer, locale string, translations map[string]*i18n.Translation) error {
+	var keys []string
+	for key := range translations {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, "msgid \"\"\nmsgstr \"\"\n%v\n%v\n", quote("Content-Type: text/plain; charset=UTF-8\n"), quote("Language: "+locale+"\n"))
+
+	for _, key := range keys {
+		translation := translations[key]
+		buf.WriteString("\n")
+		if meta := translation.Meta; meta != nil {
+			if meta.Description != "" {
+				for _, line := range strings.Split(meta.Description, "\n") {
+					fmt.Fprintf(&buf, "#. %v\n", line)
+				}
+			}
+			if meta.Source != "" {
+				fmt.Fprintf(&buf, "#: %v\n", meta.Source)
+			}
+		}
+		if translation.Context != "" {
+			fmt.Fprintf(&buf, "msgctxt %v\n", quote(translation.Context))
+		}
+		fmt.Fprintf(&buf, "msgid %v\nmsgstr %v\n", quote(translation.Key), quote(translation.Value))
+	}
+
+	_, err := w.Write(buf.Bytes())
+	return err
+}
+
+// quote quote string as PO string, multiple lines strings are split into lines after an empty string
+func quote(value string) string {
+	lines := strings.SplitAfter(value, "\n")
+	if len(lines) > 1 && lines[len(lines)-1] == "" {
+		lines = lines[:len(lines)-1]
+	}
+
+	if len(lines) == 1 {
+		return escape(value)
+	}
+
+	results := []string{`""`}
+	for _, line := range lines {
+		results = append(results, escape(line))
+	}
+	return strings.Join(results, "\n")
+}
+
+// escaper escape sequences written by gettext, other characters are written literally, e.g: no-break space, non-ASCII characters
+var escaper = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`, "\t", `\t`, "\r", `\r`)
+
+// escape escape string as a quoted PO string
+func escape(value string) string {
+	return `"` + escaper.Replace(value) + `"`
+}
+
+// unquote unquote a PO string, escape sequences accepted by gettext are unescaped, including octal `\ooo` and hex `\xhh` bytes
+func unquote(value string) (string, error) {
+	if len(value) < 2 || value[0] != '"' || value[len(value)-1] != '"' {
+		return "", fmt.Errorf("invalid string %v", value)
+	}
+
+	var (
+		result strings.Builder
+		quoted = value[1 : len(value)-1]
+	)
+
+	for i := 0; i < len(quoted); i++ {
+		c := quoted[i]
+		if c == '"' {
+			return "", fmt.Errorf("unescaped quote in string %v", value)
+		}
+
+		if c != '\\' {
+			result.WriteByte(c)
+			continue
+		}
+
+		if i++; i == len(quoted) {
+			return "", fmt.Errorf("invalid escape sequence at end of string %v", value)
+		}
+
+		switch c = quoted[i]; c {
+		case 'n':
+			result.WriteByte('\n')
+		case 't':
+			result.WriteByte('\t')
+		case 'r':
+			result.WriteByte('\r')
+		case 'a':
+			result.WriteByte('\a')
+		case 'b':
+			result.WriteByte('\b')
+		case 'f':
+			result.WriteByte('\f')
+		case 'v':
+			result.WriteByte('\v')
+		case '\\', '"', '\'', '?':
+			result.WriteByte(c)
+		case '0', '1', '2', '3', '4', '5', '6', '7':
+			// up to 3 octal digits
+			var b byte
+			for j := 0; j < 3 && i < len(quoted) && quoted[i] >= '0' && quoted[i] <= '7'; j, i = j+1, i+1 {
+				b = b*8 + quoted[i] - '0'
+			}
+			result.WriteByte(b)
+			i--
+		case 'x':
+			var b, digits = 0, 0
+			for ; i+1 < len(quoted) && isHex(quoted[i+1]); i, digits = i+1, digits+1 {
+				b = b*16 + hexValue(quoted[i+1])
+			}
+			if digits == 0 {
+				return "", fmt.Errorf("invalid hex escape sequence in string %v", value)
+			}
+			result.WriteByte(byte(b))
+		default:
+			return "", fmt.Errorf("invalid escape sequence \\%c in string %v", c, value)
+		}
+	}
+	return result.String(), nil
+}
+
+func isHex(c byte) bool {
+	return (c >= '0' && c <= '9') || (c >= 'a' && c <= 'f') || (c >= 'A' && c <= 'F')
+}
+
+func hexValue(c byte) int {
+	switch {
+	case c >= 'a':
+		return int(c-'a') + 10
+	case c >= 'A':
+		return int(c-'A') + 10
+	}
+	return int(c - '0')
+}
@@ -0,0 +1,102 @@
+package po_test
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/qor/i18n"
+	"github.com/qor/i18n/backends/po"
+)
+
+func TestLoadTranslations(t *testing.T) {
+	translations := po.New("tests").LoadTranslations()
+	if len(translations) != 4 {
+		t.Fatalf("fuzzy and plural entries should be ignored, but got %v translations", len(translations))
+	}
+
+	I18n := i18n.New(po.New("tests"))
+	if value := I18n.T("zh-CN", "hello"); value != "你好" {
+		t.Errorf("should load locale from header, but got %v", value)
+	}
+
+	if value := I18n.TC("zh-CN", "button", "open"); value != "打开" {
+		t.Errorf("should load msgctxt as context, but got %v", value)
+	}
+
+	if value := I18n.TC("zh-CN", "status", "open"); value != "营业中" {
+		t.Errorf("should load msgctxt as context, but got %v", value)
+	}
+
+	if value := I18n.T("zh-CN", "multiple lines"); value != "第一行\n第二行" {
+		t.Errorf("should load multiple lines string, but got %v", value)
+	}
+
+	if result, _ := I18n.Lookup("zh-CN", "hello"); result.Meta == nil || result.Meta.Description != "Greeting on home page" || result.Meta.Source != "app/views/home.tmpl:12" {
+		t.Errorf("should load comments as metadata, but got %#v", result.Meta)
+	}
+}
+
+func TestExport(t *testing.T) {
+	I18n := i18n.New(po.New("tests"))
+
+	var buf bytes.Buffer
+	if err := po.Export(&buf, "zh-CN", I18n.LoadTranslations()["zh-CN"]); err != nil {
+		t.Fatal(err)
+	}
+
+	translations, err := po.New().LoadPOContent(buf.Bytes(), "")
+	if err != nil {
+		t.Fatalf("failed to load exported content, got %v", err)
+	}
+
+	exported := i18n.New()
+	for _, translation := range translations {
+		exported.AddTranslation(translation)
+	}
+
+	if result, _ := exported.Lookup("zh-CN", "hello"); result.Meta == nil || result.Meta.Description != "Greeting on home page" {
+		t.Errorf("metadata should be round-tripped, but got %#v", result.Meta)
+	}
+
+	for _, key := range []string{"hello", "multiple lines"} {
+		if value, expected := exported.T("zh-CN", key), I18n.T("zh-CN", key); value != expected {
+			t.Errorf("%v should be round-tripped, expect %v, but got %v", key, expected, value)
+		}
+	}
+
+	for _, msgctxt := range []string{"button", "status"} {
+		if value, expected := exported.TC("zh-CN", msgctxt, "open"), I18n.TC("zh-CN", msgctxt, "open"); value != expected {
+			t.Errorf("context %v should be round-tripped, expect %v, but got %v", msgctxt, expected, value)
+		}
+	}
+}
+
+func TestEscape(t *testing.T) {
+	content := `msgid "escapes"
+msgstr "it\'s \"quoted\"\ttab\\ \101\x42\303\251\?"
+`
+	translations, err := po.New().LoadPOContent([]byte(content), "en-US")
+	if err != nil {
+		t.Fatalf("failed to load escape sequences accepted by gettext, got %v", err)
+	}
+
+	if len(translations) != 1 || translations[0].Value != "it's \"quoted\"\ttab\\ ABé?" {
+		t.Errorf("should unescape escape sequences, but got %#v", translations)
+	}
+
+	if _, err := po.New().LoadPOContent([]byte(`msgid "invalid \q"`+"\nmsgstr \"\"\n"), "en-US"); err == nil {
+		t.Errorf("should return error of invalid escape sequences")
+	}
+
+	var buf bytes.Buffer
+	po.Export(&buf, "en-US", map[string]*i18n.Translation{"price": {Key: "price", Locale: "en-US", Value: "100\u00a0€ \\ \"off\""}})
+	if !strings.Contains(buf.String(), "msgstr \"100\u00a0€ \\\\ \\\"off\\\"\"\n") {
+		t.Errorf("no-break space and non-ASCII characters should be written literally, but got %v", buf.String())
+	}
+
+	translations, _ = po.New().LoadPOContent(buf.Bytes(), "")
+	if len(translations) != 1 || translations[0].Value != "100\u00a0€ \\ \"off\"" {
+		t.Errorf("exported value should be round-tripped, but got %#v", translations)
+	}
+}
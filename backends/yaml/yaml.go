@@ -1,69 +1,60 @@
 package yaml
 
 import (
-	"errors"
 	"fmt"
 	"io/ioutil"
 	"net/http"
 	"os"
 	"path/filepath"
 	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
 
 	"github.com/qor/i18n"
 	"gopkg.in/yaml.v2"
 )
 
 var _ i18n.Backend = &Backend{}
+var _ i18n.Watcher = &Backend{}
+var _ i18n.LocaleLister = &Backend{}
 
-// New new YAML backend for I18n
+// New new YAML backend for I18n, parsed translations are cached, files are read again when loading translations if they changed (or Watch detected changes when watching)
 func New(paths ...string) *Backend {
-	backend := &Backend{}
-
-	var files []string
-	for _, p := range paths {
-		if file, err := os.Open(p); err == nil {
-			defer file.Close()
-			if fileInfo, err := file.Stat(); err == nil {
-				if fileInfo.IsDir() {
-					yamlFiles, _ := filepath.Glob(filepath.Join(p, "*.yaml"))
-					files = append(files, yamlFiles...)
-
-					ymlFiles, _ := filepath.Glob(filepath.Join(p, "*.yml"))
-					files = append(files, ymlFiles...)
-				} else if fileInfo.Mode().IsRegular() {
-					files = append(files, p)
-				}
-			}
-		}
-	}
-	for _, file := range files {
-		if content, err := ioutil.ReadFile(file); err == nil {
-			backend.contents = append(backend.contents, content)
-		}
-	}
-	return backend
+	return &Backend{paths: paths}
 }
 
 // NewWithWalk has the same functionality as New but uses filepath.Walk to find all the translation files recursively.
-func NewWithWalk(paths ...string) i18n.Backend {
-	backend := &Backend{}
-
-	var files []string
-	for _, p := range paths {
-		filepath.Walk(p, func(path string, fileInfo os.FileInfo, err error) error {
-			if isYamlFile(fileInfo) {
-				files = append(files, path)
+func NewWithWalk(paths ...string) *Backend {
+	return &Backend{paths: paths, walk: true}
+}
+
+// files find translation files from paths
+func (backend *Backend) files() (files []string) {
+	for _, p := range backend.paths {
+		if backend.walk {
+			filepath.Walk(p, func(path string, fileInfo os.FileInfo, err error) error {
+				if isYamlFile(fileInfo) {
+					files = append(files, path)
+				}
+				return nil
+			})
+			continue
+		}
+
+		if fileInfo, err := os.Stat(p); err == nil {
+			if fileInfo.IsDir() {
+				yamlFiles, _ := filepath.Glob(filepath.Join(p, "*.yaml"))
+				files = append(files, yamlFiles...)
+
+				ymlFiles, _ := filepath.Glob(filepath.Join(p, "*.yml"))
+				files = append(files, ymlFiles...)
+			} else if fileInfo.Mode().IsRegular() {
+				files = append(files, p)
 			}
-			return nil
-		})
-	}
-	for _, file := range files {
-		if content, err := ioutil.ReadFile(file); err == nil {
-			backend.contents = append(backend.contents, content)
 		}
 	}
-
-	return backend
+	return files
 }
 
 func isYamlFile(fileInfo os.FileInfo) bool {
@@ -111,27 +102,148 @@ func walkFilesystem(fs http.FileSystem, entry http.File, prefix string) [][]byte
 }
 
 // NewWithFilesystem initializes a backend that reads translation files from an http.FileSystem.
-func NewWithFilesystem(fss ...http.FileSystem) i18n.Backend {
-	backend := &Backend{}
-
-	for _, fs := range fss {
-		backend.contents = append(backend.contents, walkFilesystem(fs, nil, "/")...)
-	}
-	return backend
+func NewWithFilesystem(fss ...http.FileSystem) *Backend {
+	return &Backend{filesystems: fss}
 }
 
 // Backend YAML backend
 type Backend struct {
-	contents [][]byte
+	// WatchInterval interval to check changes of translation files when watching, default is one second
+	WatchInterval time.Duration
+	paths         []string
+	walk          bool
+	filesystems   []http.FileSystem
+
+	mutex        sync.Mutex
+	translations []*i18n.Translation
+	loaded       bool
+	fingerprint  string
+	watching     int32
+}
+
+// contents read contents of translation files
+func (backend *Backend) contents() (contents [][]byte) {
+	for _, file := range backend.files() {
+		if content, err := ioutil.ReadFile(file); err == nil {
+			contents = append(contents, content)
+		}
+	}
+
+	for _, fs := range backend.filesystems {
+		contents = append(contents, walkFilesystem(fs, nil, "/")...)
+	}
+	return contents
+}
+
+// currentFingerprint return modification time and size of translation files
+func (backend *Backend) currentFingerprint() string {
+	var results []string
+	for _, file := range backend.files() {
+		if fileInfo, err := os.Stat(file); err == nil {
+			results = append(results, fmt.Sprintf("%v:%v:%v", file, fileInfo.ModTime().UnixNano(), fileInfo.Size()))
+		}
+	}
+	return strings.Join(results, "\n")
+}
+
+// load parse translation files, parsed translations are cached, they are parsed again if translation files changed,
+// files won't be checked when watching, Watch will refresh them when it detects changes, files in http.FileSystem are read only once
+func (backend *Backend) load() ([]*i18n.Translation, error) {
+	backend.mutex.Lock()
+	defer backend.mutex.Unlock()
+
+	if backend.loaded && atomic.LoadInt32(&backend.watching) > 0 {
+		return backend.translations, nil
+	}
+
+	fingerprint := backend.currentFingerprint()
+	if backend.loaded && fingerprint == backend.fingerprint {
+		return backend.translations, nil
+	}
+
+	var translations []*i18n.Translation
+	for _, content := range backend.contents() {
+		results, err := backend.LoadYAMLContent(content)
+		if err != nil {
+			return nil, err
+		}
+		translations = append(translations, results...)
+	}
+
+	backend.translations, backend.fingerprint, backend.loaded = translations, fingerprint, true
+	return translations, nil
+}
+
+// refresh parse translation files again when loading translations next time
+func (backend *Backend) refresh() {
+	backend.mutex.Lock()
+	backend.loaded = false
+	backend.mutex.Unlock()
+}
+
+// Watch check modification time and size of translation files periodically, refresh cached translations and call onChange if they changed, files in http.FileSystem won't be watched
+func (backend *Backend) Watch(onChange func()) (stop func()) {
+	var (
+		done     = make(chan struct{})
+		interval = backend.WatchInterval
+		stopOnce sync.Once
+	)
+
+	if interval <= 0 {
+		interval = time.Second
+	}
+
+	last := backend.currentFingerprint()
+	atomic.AddInt32(&backend.watching, 1)
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ticker.C:
+				if current := backend.currentFingerprint(); current != last {
+					last = current
+					backend.refresh()
+					onChange()
+				}
+			case <-done:
+				return
+			}
+		}
+	}()
+
+	return func() {
+		stopOnce.Do(func() {
+			close(done)
+			atomic.AddInt32(&backend.watching, -1)
+		})
+	}
 }
 
 func loadTranslationsFromYaml(locale string, value interface{}, scopes []string) (translations []*i18n.Translation) {
 	switch v := value.(type) {
 	case yaml.MapSlice:
+		var metas map[string]*i18n.Meta
 		for _, s := range v {
+			// metadata of sibling keys, e.g: `_meta: {hello: {description: "Greeting on home page", max_length: 20}}`
+			if fmt.Sprint(s.Key) == metaKey {
+				metas = loadMetaFromYaml(s.Value)
+				continue
+			}
+
 			results := loadTranslationsFromYaml(locale, s.Value, append(scopes, fmt.Sprint(s.Key)))
 			translations = append(translations, results...)
 		}
+
+		for name, meta := range metas {
+			key := strings.Join(append(append([]string{}, scopes...), name), ".")
+			for _, translation := range translations {
+				if translation.Key == key {
+					translation.Meta = meta
+				}
+			}
+		}
 	default:
 		var translation = &i18n.Translation{
 			Locale: locale,
@@ -143,6 +255,16 @@ func loadTranslationsFromYaml(locale string, value interface{}, scopes []string)
 	return
 }
 
+// metaKey key of translations metadata in YAML files
+const metaKey = "_meta"
+
+func loadMetaFromYaml(value interface{}) (metas map[string]*i18n.Meta) {
+	if content, err := yaml.Marshal(value); err == nil {
+		yaml.Unmarshal(content, &metas)
+	}
+	return metas
+}
+
 // LoadYAMLContent load YAML content
 func (backend *Backend) LoadYAMLContent(content []byte) (translations []*i18n.Translation, err error) {
 	var slice yaml.MapSlice
@@ -156,29 +278,37 @@ func (backend *Backend) LoadYAMLContent(content []byte) (translations []*i18n.Tr
 	return translations, err
 }
 
-// LoadTranslations load translations from YAML backend
+// LoadTranslations load translations from YAML backend, cached translations are copied, so callers could change them
 func (backend *Backend) LoadTranslations() (translations []*i18n.Translation) {
-	for _, content := range backend.contents {
-		if results, err := backend.LoadYAMLContent(content); err == nil {
-			translations = append(translations, results...)
-		} else {
-			panic(err)
+	results, err := backend.load()
+	if err != nil {
+		panic(err)
+	}
+
+	for _, result := range results {
+		translation := *result
+		if result.Meta != nil {
+			meta := *result.Meta
+			translation.Meta = &meta
 		}
+		translations = append(translations, &translation)
 	}
 	return translations
 }
 
-// SaveTranslation save translation into YAML backend, not implemented
-func (backend *Backend) SaveTranslation(t *i18n.Translation) error {
-	return errors.New("not implemented")
-}
-
-// FindTranslation find translation from  backend
-func (backend *Backend) FindTranslation(t *i18n.Translation) (translation i18n.Translation) {
-	return translation //not implemented
-}
+// Locales list locales of translations in YAML backend
+func (backend *Backend) Locales() (locales []string) {
+	results, err := backend.load()
+	if err != nil {
+		panic(err)
+	}
 
-// DeleteTranslation delete translation into YAML backend, not implemented
-func (backend *Backend) DeleteTranslation(t *i18n.Translation) error {
-	return errors.New("not implemented")
+	var seen = map[string]bool{}
+	for _, result := range results {
+		if !seen[result.Locale] {
+			seen[result.Locale] = true
+			locales = append(locales, result.Locale)
+		}
+	}
+	return locales
 }
@@ -2,8 +2,12 @@ package yaml_test
 
 import (
 	"fmt"
+	"io/ioutil"
 	"net/http"
+	"os"
+	"path/filepath"
 	"testing"
+	"time"
 
 	"github.com/qor/i18n"
 	"github.com/qor/i18n/backends/yaml"
@@ -106,3 +110,113 @@ func BenchmarkLoadTranslationsFilesystem(b *testing.B) {
 	}
 	benchmarkResult3 = err
 }
+
+func TestWatch(t *testing.T) {
+	dir, err := ioutil.TempDir("", "i18n")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	file := filepath.Join(dir, "en.yml")
+	ioutil.WriteFile(file, []byte("en:\n  hello: Hello\n"), 0644)
+
+	backend := yaml.New(dir)
+	backend.WatchInterval = 10 * time.Millisecond
+	I18n := i18n.New(backend)
+
+	changed := make(chan bool, 1)
+	stop := backend.Watch(func() {
+		select {
+		case changed <- true:
+		default:
+		}
+	})
+	defer stop()
+
+	ioutil.WriteFile(file, []byte("en:\n  hello: Hello World\n"), 0644)
+	os.Chtimes(file, time.Now(), time.Now().Add(time.Second))
+
+	select {
+	case <-changed:
+	case <-time.After(time.Second):
+		t.Fatal("should notify changes of translation files")
+	}
+
+	I18n.Reload()
+	if value := I18n.T("en", "hello"); value != "Hello World" {
+		t.Errorf("should load changed translation files, but got %v", value)
+	}
+}
+
+func TestLoadTranslationsCache(t *testing.T) {
+	dir, err := ioutil.TempDir("", "i18n")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	file := filepath.Join(dir, "en.yml")
+	ioutil.WriteFile(file, []byte("en:\n  hello: Hello\n"), 0644)
+
+	backend := yaml.New(dir)
+	translations := backend.LoadTranslations()
+	translations[0].Value = "Changed"
+
+	if results := backend.LoadTranslations(); len(results) != 1 || results[0].Value != "Hello" {
+		t.Errorf("cached translations should not be changed by callers, but got %#v", results)
+	}
+
+	ioutil.WriteFile(file, []byte("en:\n  hello: Hello World\n"), 0644)
+	os.Chtimes(file, time.Now(), time.Now().Add(time.Second))
+	if results := backend.LoadTranslations(); len(results) != 1 || results[0].Value != "Hello World" {
+		t.Errorf("should parse translation files again if they changed, but got %#v", results)
+	}
+
+	backend.WatchInterval = time.Hour
+	stop := backend.Watch(func() {})
+	defer stop()
+
+	ioutil.WriteFile(file, []byte("en:\n  hello: Hi\n"), 0644)
+	os.Chtimes(file, time.Now(), time.Now().Add(2*time.Second))
+	if results := backend.LoadTranslations(); len(results) != 1 || results[0].Value != "Hello World" {
+		t.Errorf("should use cached translations until Watch detects changes, but got %#v", results)
+	}
+}
+
+func TestLoadMeta(t *testing.T) {
+	translations, err := yaml.New().LoadYAMLContent([]byte(`
+en:
+  hello: Hello
+  _meta:
+    hello:
+      description: Greeting on home page
+      max_length: 20
+      tags: [home]
+  user:
+    name: User Name
+    _meta:
+      name:
+        placeholders: ["{{.Name}}"]
+`))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(translations) != 2 {
+		t.Fatalf("_meta shouldn't be loaded as translations, but got %v", len(translations))
+	}
+
+	for _, translation := range translations {
+		switch translation.Key {
+		case "hello":
+			if meta := translation.Meta; meta == nil || meta.Description != "Greeting on home page" || meta.MaxLength != 20 || len(meta.Tags) != 1 {
+				t.Errorf("should load metadata of hello, but got %#v", meta)
+			}
+		case "user.name":
+			if meta := translation.Meta; meta == nil || len(meta.Placeholders) != 1 || meta.Placeholders[0] != "{{.Name}}" {
+				t.Errorf("should load metadata of nested key, but got %#v", meta)
+			}
+		}
+	}
+}
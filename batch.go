@@ -0,0 +1,122 @@
+package i18n
+
+import (
+	"context"
+	"fmt"
+	"sort"
+)
+
+// BatchWriter backends that could save many translations at once, e.g: DB backend saves them with upserts in a transaction
+type BatchWriter interface {
+	SaveTranslations([]*Translation) error
+}
+
+// BatchWriterV2 BackendV2 that could save many translations at once
+type BatchWriterV2 interface {
+	SaveTranslations(ctx context.Context, translations []*Translation) error
+}
+
+// batchWriterOf return batch save function of backend, translations are saved one by one if backend is not a batch writer, return nil if backend is not writable
+func batchWriterOf(backend Backend) func(context.Context, []*Translation) error {
+	if adapter, ok := backend.(*backendV2Adapter); ok {
+		if writer, ok := adapter.backend.(BatchWriterV2); ok {
+			return writer.SaveTranslations
+		}
+	} else if writer, ok := backend.(BatchWriter); ok {
+		return func(ctx context.Context, translations []*Translation) error {
+			if err := ctx.Err(); err != nil {
+				return err
+			}
+			return writer.SaveTranslations(translations)
+		}
+	}
+
+	if save := writerOf(backend); save != nil {
+		return func(ctx context.Context, translations []*Translation) error {
+			for _, translation := range translations {
+				if err := save(ctx, translation); err != nil {
+					return err
+				}
+			}
+			return nil
+		}
+	}
+	return nil
+}
+
+// SaveTranslations save translations into their first writable backends, backends that implement BatchWriter save them at once
+func (i18n *I18n) SaveTranslations(translations []*Translation) error {
+	return i18n.SaveTranslationsContext(context.Background(), translations)
+}
+
+// SaveTranslationsContext save translations into their first writable backends with context, translations are saved into next writable backends if failed to save them
+// other nodes are notified to reload translations of changed locales, instead of updating translations one by one
+func (i18n *I18n) SaveTranslationsContext(ctx context.Context, translations []*Translation) (err error) {
+	var (
+		from    = map[*Translation]int{}
+		locales []string
+		changed = map[string]bool{}
+	)
+
+	defer func() {
+		for _, locale := range locales {
+			i18n.publish(ChangeEvent{Locale: locale})
+		}
+	}()
+
+	for len(translations) > 0 {
+		var (
+			batches = map[int][]*Translation{}
+			indexes []int
+			failed  []*Translation
+		)
+
+		for _, translation := range translations {
+			idx := i18n.writableBackendFrom(translation, from[translation])
+			if idx == -1 {
+				if err == nil {
+					err = fmt.Errorf("failed to save translation %v: no writable backend", translation.Key)
+				}
+				return err
+			}
+
+			if _, ok := batches[idx]; !ok {
+				indexes = append(indexes, idx)
+			}
+			batches[idx] = append(batches[idx], translation)
+		}
+		sort.Ints(indexes)
+
+		for _, idx := range indexes {
+			backend := i18n.Backends[idx]
+			if err = batchWriterOf(backend)(ctx, batches[idx]); err != nil {
+				for _, translation := range batches[idx] {
+					from[translation] = idx + 1
+				}
+				failed = append(failed, batches[idx]...)
+				continue
+			}
+
+			for _, translation := range batches[idx] {
+				translation.Backend = backend
+				i18n.AddTranslation(translation)
+				if !changed[translation.Locale] {
+					changed[translation.Locale] = true
+					locales = append(locales, translation.Locale)
+				}
+			}
+		}
+		translations = failed
+	}
+	return nil
+}
+
+// writableBackendFrom return index of first writable backend of translation from index, return -1 if not found
+func (i18n *I18n) writableBackendFrom(translation *Translation, from int) int {
+	for idx := from; idx < len(i18n.Backends); idx++ {
+		if writable(i18n.Backends[idx], translation) {
+			return idx
+		}
+	}
+	return -1
+}
@@ -0,0 +1,116 @@
+package i18n
+
+import (
+	"errors"
+	"sync"
+	"testing"
+)
+
+type batchBackend struct {
+	memoryBackend
+	batches int
+}
+
+func (b *batchBackend) SaveTranslations(translations []*Translation) error {
+	b.batches++
+	for _, t := range translations {
+		b.memoryBackend.SaveTranslation(t)
+	}
+	return nil
+}
+
+func TestSaveTranslations(t *testing.T) {
+	var (
+		files = &readOnlyBackend{translations: []*Translation{{Key: "hello", Locale: "en-US", Value: "Hello"}}}
+		db    = &batchBackend{memoryBackend: memoryBackend{translations: map[string]*Translation{}}}
+		i18n  = New(files, db)
+	)
+
+	err := i18n.SaveTranslations([]*Translation{
+		{Key: "hello", Locale: "zh-CN", Value: "你好"},
+		{Key: "bye", Locale: "zh-CN", Value: "再见"},
+		{Key: "bye", Locale: "en-US", Value: "Bye"},
+	})
+	if err != nil {
+		t.Fatalf("failed to save translations, got %v", err)
+	}
+
+	if db.batches != 1 || len(db.translations) != 3 {
+		t.Errorf("should save translations at once, but got %v batches, %v translations", db.batches, len(db.translations))
+	}
+
+	if value := i18n.T("zh-CN", "bye"); value != "再见" {
+		t.Errorf("saved translations should be added, but got %v", value)
+	}
+
+	if result, _ := i18n.Lookup("en-US", "bye"); result.Backend != db {
+		t.Errorf("backend of saved translations should be set, but got %v", result.Backend)
+	}
+
+	memory := &memoryBackend{translations: map[string]*Translation{}}
+	if err := New(memory).SaveTranslations([]*Translation{{Key: "hello", Locale: "en-US", Value: "Hello"}, {Key: "bye", Locale: "en-US", Value: "Bye"}}); err != nil || len(memory.translations) != 2 {
+		t.Errorf("translations should be saved one by one if backend is not a batch writer, but got %v, %v", len(memory.translations), err)
+	}
+
+	if err := New(files).SaveTranslations([]*Translation{{Key: "bye", Locale: "en-US", Value: "Bye"}}); err == nil {
+		t.Errorf("should return error if there is no writable backend")
+	}
+
+	failing := &failingBatchBackend{}
+	fallback := &batchBackend{memoryBackend: memoryBackend{translations: map[string]*Translation{}}}
+	i18n = New(failing, fallback)
+	if err := i18n.SaveTranslations([]*Translation{{Key: "hello", Locale: "en-US", Value: "Hello"}}); err != nil || len(fallback.translations) != 1 {
+		t.Errorf("should save translations into next writable backend if failed, but got %v", err)
+	}
+
+	if result, _ := i18n.Lookup("en-US", "hello"); result.Backend != fallback {
+		t.Errorf("backend of translations saved into next writable backend should be set, but got %v", result.Backend)
+	}
+
+	if err := New(failing).SaveTranslations([]*Translation{{Key: "hello", Locale: "en-US", Value: "Hello"}}); err == nil || err.Error() != "failed to save translations" {
+		t.Errorf("should return error of backend if failed to save translations into all backends, but got %v", err)
+	}
+}
+
+type failingBatchBackend struct {
+	readOnlyBackend
+}
+
+func (b *failingBatchBackend) SaveTranslation(t *Translation) error {
+	return errors.New("failed to save translation")
+}
+
+func (b *failingBatchBackend) SaveTranslations(translations []*Translation) error {
+	return errors.New("failed to save translations")
+}
+
+func TestSaveTranslationsNotify(t *testing.T) {
+	var (
+		notifier = NewMemoryNotifier()
+		db       = &memoryBackend{translations: map[string]*Translation{}}
+		node1    = New(db)
+		node2    = New(db)
+		events   []ChangeEvent
+		mutex    sync.Mutex
+	)
+
+	node1.UseNotifier(notifier)
+	node2.UseNotifier(notifier)
+	notifier.Subscribe(func(event ChangeEvent) {
+		mutex.Lock()
+		events = append(events, event)
+		mutex.Unlock()
+	})
+
+	node1.SaveTranslations([]*Translation{{Key: "hello", Locale: "en-US", Value: "Hello"}, {Key: "bye", Locale: "en-US", Value: "Bye"}})
+
+	mutex.Lock()
+	defer mutex.Unlock()
+	if len(events) != 1 || events[0].Locale != "en-US" || events[0].Key != "" {
+		t.Errorf("should publish one event to reload translations of changed locale, but got %v", events)
+	}
+
+	if value := node2.T("en-US", "bye"); value != "Bye" {
+		t.Errorf("other nodes should reload translations, but got %v", value)
+	}
+}
@@ -0,0 +1,78 @@
+package i18n
+
+import "reflect"
+
+// Compiler is a formatter that could compile translation value into reusable template, compiled templates are cached by I18n until the translation is changed
+type Compiler interface {
+	Formatter
+	Compile(locale, value string) (CompiledTemplate, error)
+}
+
+// CompiledTemplate compiled translation template
+type CompiledTemplate interface {
+	Execute(args ...interface{}) (string, error)
+}
+
+// staticTemplate translation without any placeholders
+type staticTemplate string
+
+// Execute return translation as it is
+func (tmpl staticTemplate) Execute(args ...interface{}) (string, error) {
+	return string(tmpl), nil
+}
+
+// formatterTemplate template that format translation with formatter every time
+type formatterTemplate struct {
+	formatter Formatter
+	locale    string
+	value     string
+}
+
+// Execute format translation with formatter
+func (tmpl *formatterTemplate) Execute(args ...interface{}) (string, error) {
+	return tmpl.formatter.Format(tmpl.locale, tmpl.value, args...)
+}
+
+type compiledTranslation struct {
+	compiler Compiler
+	value    string
+	template CompiledTemplate
+	err      error
+}
+
+// format format translation value with formatter, compiled template will be reused if the formatter is a Compiler
+func (i18n *I18n) format(locale, key, value string, args ...interface{}) (string, error) {
+	formatter := i18n.getFormatter()
+
+	compiler, ok := formatter.(Compiler)
+	if !ok || i18n.compiledTranslations == nil || !reflect.TypeOf(compiler).Comparable() {
+		return formatter.Format(locale, value, args...)
+	}
+
+	var (
+		name     = cacheKey(locale, key)
+		compiled *compiledTranslation
+	)
+
+	if c, ok := i18n.compiledTranslations.Load(name); ok {
+		compiled = c.(*compiledTranslation)
+	}
+
+	if compiled == nil || compiled.value != value || compiled.compiler != compiler {
+		compiled = &compiledTranslation{compiler: compiler, value: value}
+		compiled.template, compiled.err = compiler.Compile(locale, value)
+		i18n.compiledTranslations.Store(name, compiled)
+	}
+
+	if compiled.err != nil {
+		return value, compiled.err
+	}
+	return compiled.template.Execute(args...)
+}
+
+// invalidateCompiledTranslation remove compiled template of translation
+func (i18n *I18n) invalidateCompiledTranslation(translation *Translation) {
+	if i18n.compiledTranslations != nil {
+		i18n.compiledTranslations.Delete(cacheKey(translation.Locale, translation.messageKey()))
+	}
+}
@@ -0,0 +1,517 @@
+package i18n
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+var (
+	_ Backend         = &Overlay{}
+	_ WritableChecker = &Overlay{}
+	_ Backend         = &Router{}
+	_ WritableChecker = &Router{}
+	_ Backend         = &ReadThrough{}
+	_ WritableChecker = &ReadThrough{}
+	_ contextLoader   = &Overlay{}
+	_ contextLoader   = &Router{}
+	_ contextLoader   = &ReadThrough{}
+)
+
+// contextLoader backends that load translations of locales with context and return errors, composite backends implement it, so errors of their backends are returned from I18n
+type contextLoader interface {
+	loadContext(ctx context.Context, locales []string) ([]*Translation, error)
+}
+
+// logLoaded return translations loaded by composite backend, errors are logged, I18n returns the error instead
+func logLoaded(translations []*Translation, err error) []*Translation {
+	if err != nil {
+		log.Printf("i18n: %v", err)
+	}
+	return translations
+}
+
+// errReadOnly error returned when saving or deleting translation from read-only backend
+var errReadOnly = errors.New("backend is read only")
+
+// mergeTranslations merge translations of backends, translations of backends in the front have higher priority
+func mergeTranslations(ctx context.Context, backends []Backend, locales []string) (translations []*Translation, err error) {
+	var (
+		results = map[string]*Translation{}
+		keys    []string
+	)
+
+	for i := len(backends) - 1; i >= 0; i-- {
+		loaded, err := loadLocaleTranslations(ctx, backends[i], locales)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, translation := range loaded {
+			key := cacheKey(translation.Locale, translation.messageKey())
+			if _, ok := results[key]; !ok {
+				keys = append(keys, key)
+			}
+			results[key] = translation
+		}
+	}
+
+	for _, key := range keys {
+		translations = append(translations, results[key])
+	}
+	return translations, nil
+}
+
+// watchBackends watch all watchable backends, return a function to stop watching
+func watchBackends(backends []Backend, onChange func()) func() {
+	var stops []func()
+	for _, backend := range backends {
+		if watcher, ok := backend.(Watcher); ok {
+			stops = append(stops, watcher.Watch(onChange))
+		}
+	}
+
+	return func() {
+		for _, stop := range stops {
+			stop()
+		}
+	}
+}
+
+// Overlay backend that has a writable top layer over read-only base backends, translations of top layer have higher priority, e.g:
+//
+//	i18n.New(i18n.NewOverlay(database.New(db), yaml.New("config/locales")))
+type Overlay struct {
+	Top  Backend
+	Base []Backend
+}
+
+// NewOverlay initialize overlay backend, translations are saved into top backend, base backends are read only, base backends in the front have higher priority
+func NewOverlay(top Backend, base ...Backend) *Overlay {
+	return &Overlay{Top: top, Base: base}
+}
+
+func (overlay *Overlay) backends() (backends []Backend) {
+	for _, backend := range append([]Backend{overlay.Top}, overlay.Base...) {
+		if backend != nil {
+			backends = append(backends, backend)
+		}
+	}
+	return backends
+}
+
+func (overlay *Overlay) loadContext(ctx context.Context, locales []string) ([]*Translation, error) {
+	return mergeTranslations(ctx, overlay.backends(), locales)
+}
+
+// LoadTranslations load translations from all layers, errors of layers are logged
+func (overlay *Overlay) LoadTranslations() []*Translation {
+	return logLoaded(overlay.loadContext(context.Background(), nil))
+}
+
+// LoadLocale load translations of locale from all layers, errors of layers are logged
+func (overlay *Overlay) LoadLocale(locale string) []*Translation {
+	return logLoaded(overlay.loadContext(context.Background(), []string{locale}))
+}
+
+// SaveTranslation save translation into top layer
+func (overlay *Overlay) SaveTranslation(translation *Translation) error {
+	if save := writerOf(overlay.Top); save != nil {
+		return save(context.Background(), translation)
+	}
+	return errReadOnly
+}
+
+// DeleteTranslation delete translation from top layer, translation of base layers will be used after deleted
+func (overlay *Overlay) DeleteTranslation(translation *Translation) error {
+	if del := deleterOf(overlay.Top); del != nil {
+		return del(context.Background(), translation)
+	}
+	return errReadOnly
+}
+
+// FindTranslation find translation from top layer, then base layers
+func (overlay *Overlay) FindTranslation(translation *Translation) Translation {
+	for _, backend := range overlay.backends() {
+		if result, ok, _ := findTranslation(context.Background(), backend, translation); ok {
+			return result
+		}
+	}
+	return Translation{}
+}
+
+// Writable return true if top layer is writable
+func (overlay *Overlay) Writable(translation *Translation) bool {
+	return writable(overlay.Top, translation)
+}
+
+// Watch watch changes of all layers
+func (overlay *Overlay) Watch(onChange func()) func() {
+	return watchBackends(overlay.backends(), onChange)
+}
+
+// Router backend that routes translations to backends by prefix of key, e.g:
+//
+//	// `qor_*` translations are read from YAML files shipped with the binary, others are saved into database
+//	i18n.New(i18n.NewRouter(database.New(db)).Route("qor_", yaml.New("config/qor_locales")))
+type Router struct {
+	Default Backend
+	routes  []route
+}
+
+type route struct {
+	prefix  string
+	backend Backend
+}
+
+// NewRouter initialize router backend, translations that don't match any routes will be routed to default backend
+func NewRouter(defaultBackend Backend) *Router {
+	return &Router{Default: defaultBackend}
+}
+
+// Route route translations whose key has prefix to backend, the longest matched prefix wins
+func (router *Router) Route(prefix string, backend Backend) *Router {
+	router.routes = append(router.routes, route{prefix: prefix, backend: backend})
+	sort.SliceStable(router.routes, func(i, j int) bool {
+		return len(router.routes[i].prefix) > len(router.routes[j].prefix)
+	})
+	return router
+}
+
+// backendOf return backend of key, return nil if no backend matched
+func (router *Router) backendOf(key string) Backend {
+	for _, r := range router.routes {
+		if strings.HasPrefix(key, r.prefix) {
+			return r.backend
+		}
+	}
+	return router.Default
+}
+
+func (router *Router) backends() (backends []Backend) {
+	for _, r := range router.routes {
+		backends = append(backends, r.backend)
+	}
+	if router.Default != nil {
+		backends = append(backends, router.Default)
+	}
+	return backends
+}
+
+func (router *Router) loadContext(ctx context.Context, locales []string) (translations []*Translation, err error) {
+	var loaded []Backend
+	for _, backend := range router.backends() {
+		var isLoaded bool
+		for _, b := range loaded {
+			isLoaded = isLoaded || sameBackend(b, backend)
+		}
+		if isLoaded {
+			continue
+		}
+		loaded = append(loaded, backend)
+
+		results, err := loadLocaleTranslations(ctx, backend, locales)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, translation := range results {
+			// ignore translations that are not routed to the backend
+			if sameBackend(router.backendOf(translation.Key), backend) {
+				translations = append(translations, translation)
+			}
+		}
+	}
+	return translations, nil
+}
+
+// LoadTranslations load translations from all routed backends, errors of backends are logged
+func (router *Router) LoadTranslations() []*Translation {
+	return logLoaded(router.loadContext(context.Background(), nil))
+}
+
+// LoadLocale load translations of locale from all routed backends, errors of backends are logged
+func (router *Router) LoadLocale(locale string) []*Translation {
+	return logLoaded(router.loadContext(context.Background(), []string{locale}))
+}
+
+// SaveTranslation save translation into routed backend
+func (router *Router) SaveTranslation(translation *Translation) error {
+	if backend := router.backendOf(translation.Key); backend != nil {
+		if save := writerOf(backend); save != nil {
+			return save(context.Background(), translation)
+		}
+	}
+	return fmt.Errorf("failed to save translation %v: %v", translation.Key, errReadOnly)
+}
+
+// DeleteTranslation delete translation from routed backend
+func (router *Router) DeleteTranslation(translation *Translation) error {
+	if backend := router.backendOf(translation.Key); backend != nil {
+		if del := deleterOf(backend); del != nil {
+			return del(context.Background(), translation)
+		}
+	}
+	return fmt.Errorf("failed to delete translation %v: %v", translation.Key, errReadOnly)
+}
+
+// FindTranslation find translation from routed backend
+func (router *Router) FindTranslation(translation *Translation) Translation {
+	if backend := router.backendOf(translation.Key); backend != nil {
+		if result, ok, _ := findTranslation(context.Background(), backend, translation); ok {
+			return result
+		}
+	}
+	return Translation{}
+}
+
+// Writable return true if routed backend of translation is writable
+func (router *Router) Writable(translation *Translation) bool {
+	backend := router.backendOf(translation.Key)
+	return backend != nil && writable(backend, translation)
+}
+
+// Watch watch changes of all routed backends
+func (router *Router) Watch(onChange func()) func() {
+	return watchBackends(router.backends(), onChange)
+}
+
+// ReadThrough backend that caches translations of backend in memory, translations are loaded from backend when cache missed or expired,
+// changes are written to backend and cache, it is useful for slow backends, e.g: remote translation services
+type ReadThrough struct {
+	Backend Backend
+	// TTL how long cached translations are valid, cached translations never expire if it is zero
+	TTL     time.Duration
+	mutex   sync.RWMutex
+	locales map[string]*readThroughEntry
+	all     *readThroughEntry
+}
+
+// readThroughEntry cached translations `map[locale]map[key]*Translation`, they are copied on write per locale as they might be used by readers
+type readThroughEntry struct {
+	translations map[string]map[string]*Translation
+	expiredAt    time.Time
+}
+
+func (entry *readThroughEntry) valid() bool {
+	return entry != nil && (entry.expiredAt.IsZero() || time.Now().Before(entry.expiredAt))
+}
+
+// NewReadThrough initialize read-through cache of backend
+func NewReadThrough(backend Backend, ttl time.Duration) *ReadThrough {
+	return &ReadThrough{Backend: backend, TTL: ttl}
+}
+
+func (readThrough *ReadThrough) newEntry(translations []*Translation) *readThroughEntry {
+	entry := &readThroughEntry{translations: map[string]map[string]*Translation{}}
+	if readThrough.TTL > 0 {
+		entry.expiredAt = time.Now().Add(readThrough.TTL)
+	}
+	for _, translation := range translations {
+		if entry.translations[translation.Locale] == nil {
+			entry.translations[translation.Locale] = map[string]*Translation{}
+		}
+		entry.translations[translation.Locale][translation.messageKey()] = translation
+	}
+	return entry
+}
+
+func (entry *readThroughEntry) list(locale string) (translations []*Translation) {
+	var locales []string
+	if locale == "" {
+		for l := range entry.translations {
+			locales = append(locales, l)
+		}
+		sort.Strings(locales)
+	} else {
+		locales = []string{locale}
+	}
+
+	for _, l := range locales {
+		var keys []string
+		for key := range entry.translations[l] {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+
+		for _, key := range keys {
+			translations = append(translations, entry.translations[l][key].copy())
+		}
+	}
+	return translations
+}
+
+// loadContext load translations of locales from cache, or backend if cache missed or expired, load all translations if locales is nil
+func (readThrough *ReadThrough) loadContext(ctx context.Context, locales []string) (translations []*Translation, err error) {
+	if locales == nil {
+		readThrough.mutex.RLock()
+		entry := readThrough.all
+		readThrough.mutex.RUnlock()
+
+		if !entry.valid() {
+			results, err := loadTranslations(ctx, readThrough.Backend)
+			if err != nil {
+				return nil, err
+			}
+
+			entry = readThrough.newEntry(results)
+			readThrough.mutex.Lock()
+			readThrough.all = entry
+			readThrough.locales = nil
+			readThrough.mutex.Unlock()
+		}
+		return entry.list(""), nil
+	}
+
+	for _, locale := range locales {
+		readThrough.mutex.RLock()
+		entry, all := readThrough.locales[locale], readThrough.all
+		readThrough.mutex.RUnlock()
+
+		if all.valid() {
+			translations = append(translations, all.list(locale)...)
+			continue
+		}
+
+		if !entry.valid() {
+			results, err := loadLocaleTranslations(ctx, readThrough.Backend, []string{locale})
+			if err != nil {
+				return nil, err
+			}
+
+			entry = readThrough.newEntry(results)
+			readThrough.mutex.Lock()
+			if readThrough.locales == nil {
+				readThrough.locales = map[string]*readThroughEntry{}
+			}
+			readThrough.locales[locale] = entry
+			readThrough.mutex.Unlock()
+		}
+		translations = append(translations, entry.list(locale)...)
+	}
+	return translations, nil
+}
+
+// LoadTranslations load translations from cache, or backend if cache missed or expired, errors of backend are logged
+func (readThrough *ReadThrough) LoadTranslations() []*Translation {
+	return logLoaded(readThrough.loadContext(context.Background(), nil))
+}
+
+// LoadLocale load translations of locale from cache, or backend if cache missed or expired, errors of backend are logged
+func (readThrough *ReadThrough) LoadLocale(locale string) []*Translation {
+	return logLoaded(readThrough.loadContext(context.Background(), []string{locale}))
+}
+
+// FindTranslation find translation from cache, or backend if cache missed or expired
+func (readThrough *ReadThrough) FindTranslation(translation *Translation) Translation {
+	key := translation.messageKey()
+
+	readThrough.mutex.RLock()
+	for _, entry := range []*readThroughEntry{readThrough.all, readThrough.locales[translation.Locale]} {
+		if entry.valid() {
+			if t, ok := entry.translations[translation.Locale][key]; ok {
+				readThrough.mutex.RUnlock()
+				return *t.copy()
+			}
+		}
+	}
+	readThrough.mutex.RUnlock()
+
+	result, _, _ := findTranslation(context.Background(), readThrough.Backend, translation)
+	return result
+}
+
+// SaveTranslation save translation into backend, and update cache
+func (readThrough *ReadThrough) SaveTranslation(translation *Translation) error {
+	save := writerOf(readThrough.Backend)
+	if save == nil {
+		return errReadOnly
+	}
+
+	if err := save(context.Background(), translation); err != nil {
+		return err
+	}
+
+	readThrough.update(translation, false)
+	return nil
+}
+
+// DeleteTranslation delete translation from backend, and update cache
+func (readThrough *ReadThrough) DeleteTranslation(translation *Translation) error {
+	del := deleterOf(readThrough.Backend)
+	if del == nil {
+		return errReadOnly
+	}
+
+	if err := del(context.Background(), translation); err != nil {
+		return err
+	}
+
+	readThrough.update(translation, true)
+	return nil
+}
+
+// update update cached translation, only translations of its locale are copied, others are shared with readers
+func (readThrough *ReadThrough) update(translation *Translation, deleted bool) {
+	readThrough.mutex.Lock()
+	defer readThrough.mutex.Unlock()
+
+	var (
+		locale  = translation.Locale
+		key     = translation.messageKey()
+		updated = func(entry *readThroughEntry) *readThroughEntry {
+			if entry == nil {
+				return nil
+			}
+
+			result := &readThroughEntry{translations: make(map[string]map[string]*Translation, len(entry.translations)+1), expiredAt: entry.expiredAt}
+			for l, values := range entry.translations {
+				result.translations[l] = values
+			}
+
+			values := make(map[string]*Translation, len(entry.translations[locale])+1)
+			for k, t := range entry.translations[locale] {
+				values[k] = t
+			}
+
+			if deleted {
+				delete(values, key)
+			} else {
+				values[key] = &Translation{Key: translation.Key, Locale: locale, Value: translation.Value, Context: translation.Context, Meta: translation.Meta}
+			}
+			result.translations[locale] = values
+			return result
+		}
+	)
+
+	readThrough.all = updated(readThrough.all)
+	if entry, ok := readThrough.locales[locale]; ok {
+		readThrough.locales[locale] = updated(entry)
+	}
+}
+
+// Invalidate clear cached translations
+func (readThrough *ReadThrough) Invalidate() {
+	readThrough.mutex.Lock()
+	defer readThrough.mutex.Unlock()
+	readThrough.all = nil
+	readThrough.locales = nil
+}
+
+// Writable return true if backend is writable
+func (readThrough *ReadThrough) Writable(translation *Translation) bool {
+	return writable(readThrough.Backend, translation)
+}
+
+// Watch watch changes of backend, cache will be cleared when changed
+func (readThrough *ReadThrough) Watch(onChange func()) func() {
+	return watchBackends([]Backend{readThrough.Backend}, func() {
+		readThrough.Invalidate()
+		onChange()
+	})
+}
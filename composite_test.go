@@ -0,0 +1,195 @@
+package i18n
+
+import (
+	"context"
+	"reflect"
+	"testing"
+	"time"
+)
+
+type countBackend struct {
+	memoryBackend
+	loads int
+}
+
+func (b *countBackend) LoadTranslations() []*Translation {
+	b.loads++
+	return b.memoryBackend.LoadTranslations()
+}
+
+func TestOverlay(t *testing.T) {
+	var (
+		top   = &memoryBackend{translations: map[string]*Translation{}}
+		files = &readOnlyBackend{translations: []*Translation{{Key: "hello", Locale: "en-US", Value: "Hello"}, {Key: "bye", Locale: "en-US", Value: "Bye"}}}
+		i18n  = New(NewOverlay(top, files))
+	)
+
+	if !i18n.Editable("en-US", "hello") {
+		t.Errorf("translations of overlay should be editable")
+	}
+
+	i18n.SaveTranslation(&Translation{Key: "hello", Locale: "en-US", Value: "Hello World"})
+	if top.translations["en-US/hello"] == nil || i18n.T("en-US", "hello") != "Hello World" {
+		t.Errorf("translation should be saved into top layer")
+	}
+
+	i18n.Reload()
+	if value := i18n.T("en-US", "hello"); value != "Hello World" {
+		t.Errorf("translations of top layer should have higher priority, but got %v", value)
+	}
+
+	if translation := NewOverlay(top, files).FindTranslation(&Translation{Key: "bye", Locale: "en-US"}); translation.Value != "Bye" {
+		t.Errorf("should find translation from base layers, but got %v", translation.Value)
+	}
+
+	i18n.DeleteTranslation(&Translation{Key: "hello", Locale: "en-US"})
+	i18n.Reload()
+	if value := i18n.T("en-US", "hello"); value != "Hello" {
+		t.Errorf("translation of base layers should be used after deleted from top layer, but got %v", value)
+	}
+}
+
+func TestRouter(t *testing.T) {
+	var (
+		db    = &memoryBackend{translations: map[string]*Translation{}}
+		files = &readOnlyBackend{translations: []*Translation{{Key: "qor_admin.title", Locale: "en-US", Value: "Admin"}, {Key: "hello", Locale: "en-US", Value: "Hello from files"}}}
+	)
+
+	db.SaveTranslation(&Translation{Key: "qor_admin.title", Locale: "en-US", Value: "Admin from DB"})
+	db.SaveTranslation(&Translation{Key: "hello", Locale: "en-US", Value: "Hello"})
+
+	i18n := New(NewRouter(db).Route("qor_", files))
+	if value := i18n.T("en-US", "qor_admin.title"); value != "Admin" {
+		t.Errorf("translations should be loaded from routed backend, but got %v", value)
+	}
+
+	if value := i18n.T("en-US", "hello"); value != "Hello" {
+		t.Errorf("translations should be loaded from default backend, but got %v", value)
+	}
+
+	if i18n.Editable("en-US", "qor_admin.title") || !i18n.Editable("en-US", "hello") {
+		t.Errorf("translations routed to read-only backend shouldn't be editable")
+	}
+
+	if err := i18n.SaveTranslation(&Translation{Key: "qor_admin.title", Locale: "en-US", Value: "Changed"}); err == nil {
+		t.Errorf("should return error when saving translation into read-only backend")
+	}
+
+	if err := i18n.SaveTranslation(&Translation{Key: "bye", Locale: "en-US", Value: "Bye"}); err != nil || db.translations["en-US/bye"] == nil {
+		t.Errorf("should save translation into default backend, got %v", err)
+	}
+
+	// nested composite backends
+	top := &memoryBackend{translations: map[string]*Translation{}}
+	i18n = New(NewRouter(db).Route("qor_", NewOverlay(top, files)))
+	if err := i18n.SaveTranslation(&Translation{Key: "qor_admin.title", Locale: "en-US", Value: "Changed"}); err != nil || top.translations["en-US/qor_admin.title"] == nil {
+		t.Errorf("should save translation into nested overlay, got %v", err)
+	}
+}
+
+func TestReadThrough(t *testing.T) {
+	b := &countBackend{memoryBackend: memoryBackend{translations: map[string]*Translation{}}}
+	b.SaveTranslation(&Translation{Key: "hello", Locale: "en-US", Value: "Hello"})
+
+	readThrough := NewReadThrough(b, 20*time.Millisecond)
+	readThrough.LoadTranslations()
+	readThrough.LoadLocale("en-US")
+	if translation := readThrough.FindTranslation(&Translation{Key: "hello", Locale: "en-US"}); translation.Value != "Hello" || b.loads != 1 {
+		t.Errorf("should read translations from cache, loaded %v times", b.loads)
+	}
+
+	readThrough.SaveTranslation(&Translation{Key: "hello", Locale: "en-US", Value: "Hello World"})
+	if translations := readThrough.LoadTranslations(); len(translations) != 1 || translations[0].Value != "Hello World" || b.translations["en-US/hello"].Value != "Hello World" || b.loads != 1 {
+		t.Errorf("should write translation into backend and cache")
+	}
+
+	readThrough.DeleteTranslation(&Translation{Key: "hello", Locale: "en-US"})
+	if translations := readThrough.LoadTranslations(); len(translations) != 0 {
+		t.Errorf("should delete translation from cache")
+	}
+
+	time.Sleep(30 * time.Millisecond)
+	readThrough.LoadTranslations()
+	if b.loads != 2 {
+		t.Errorf("should load translations from backend after cache expired, loaded %v times", b.loads)
+	}
+
+	readThrough.Invalidate()
+	readThrough.LoadTranslations()
+	if b.loads != 3 {
+		t.Errorf("should load translations from backend after cache invalidated, loaded %v times", b.loads)
+	}
+
+	readThrough.SaveTranslation(&Translation{Key: "hello", Locale: "zh-CN", Value: "你好"})
+	cached := reflect.ValueOf(readThrough.all.translations["zh-CN"]).Pointer()
+	readThrough.SaveTranslation(&Translation{Key: "hello", Locale: "en-US", Value: "Hello"})
+	if reflect.ValueOf(readThrough.all.translations["zh-CN"]).Pointer() != cached {
+		t.Errorf("only cached translations of changed locale should be copied")
+	}
+
+	if NewReadThrough(&readOnlyBackend{}, 0).SaveTranslation(&Translation{Key: "hello", Locale: "en-US"}) == nil {
+		t.Errorf("should return error when backend is read only")
+	}
+}
+
+func TestCompositeErrors(t *testing.T) {
+	composites := map[string]Backend{
+		"overlay":      NewOverlay(&memoryBackend{translations: map[string]*Translation{}}, panicBackend{}),
+		"router":       NewRouter(&memoryBackend{translations: map[string]*Translation{}}).Route("qor_", panicBackend{}),
+		"read through": NewReadThrough(panicBackend{}, 0),
+	}
+
+	for name, composite := range composites {
+		if _, err := NewWithError(composite); err == nil {
+			t.Errorf("%v: should return errors of backends", name)
+		}
+
+		b := &memoryBackend{translations: map[string]*Translation{}}
+		b.SaveTranslation(&Translation{Key: "hello", Locale: "en-US", Value: "Hello"})
+		i18n := New(b)
+		i18n.Backends = []Backend{composite}
+		if err := i18n.Reload(); err == nil {
+			t.Errorf("%v: should return errors of backends when reloading", name)
+		}
+
+		if value := i18n.T("en-US", "hello"); value != "Hello" {
+			t.Errorf("%v: translations shouldn't be changed if failed to reload, but got %v", name, value)
+		}
+
+		if len(composite.LoadTranslations()) != 0 || len(i18n.SearchTranslations("en-US", "hello")) != 0 {
+			t.Errorf("%v: no translations should be returned if failed to load them", name)
+		}
+
+		lazy := NewLazy(nil, b)
+		lazy.Backends = []Backend{composite}
+		if err := lazy.LoadLocales(context.Background(), "en-US"); err == nil {
+			t.Errorf("%v: should return errors of backends when loading locales", name)
+		}
+	}
+}
+
+func TestReadThroughCopies(t *testing.T) {
+	b := &memoryBackend{translations: map[string]*Translation{}}
+	b.SaveTranslation(&Translation{Key: "hello", Locale: "en-US", Value: "Hello"})
+
+	readThrough := NewReadThrough(b, 0)
+	readThrough.LoadTranslations()[0].Value = "changed"
+	if translation := readThrough.FindTranslation(&Translation{Key: "hello", Locale: "en-US"}); translation.Value != "Hello" {
+		t.Errorf("cached translations shouldn't be changed by callers, but got %v", translation.Value)
+	}
+
+	i18n := New(readThrough)
+	done := make(chan bool)
+	go func() {
+		for i := 0; i < 100; i++ {
+			readThrough.FindTranslation(&Translation{Key: "hello", Locale: "en-US"})
+		}
+		close(done)
+	}()
+
+	for i := 0; i < 100; i++ {
+		i18n.Reload()
+		i18n.SearchTranslations("en-US", "hello")
+	}
+	<-done
+}
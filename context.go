@@ -0,0 +1,193 @@
+package i18n
+
+import (
+	"context"
+	"html/template"
+	"net/http"
+	"strings"
+
+	"golang.org/x/text/language"
+)
+
+type contextKey int
+
+const (
+	localeContextKey contextKey = iota
+	fallbacksContextKey
+	scopeContextKey
+	defaultValueContextKey
+)
+
+// WithLocale returns a copy of ctx that carries locale
+func WithLocale(ctx context.Context, locale string) context.Context {
+	return context.WithValue(ctx, localeContextKey, locale)
+}
+
+// LocaleFromContext get locale from ctx, return Default if it's not set
+func LocaleFromContext(ctx context.Context) string {
+	if ctx != nil {
+		if locale, ok := ctx.Value(localeContextKey).(string); ok && locale != "" {
+			return locale
+		}
+	}
+	return Default
+}
+
+// WithFallbacks returns a copy of ctx that carries fallback locales
+func WithFallbacks(ctx context.Context, locales ...string) context.Context {
+	return context.WithValue(ctx, fallbacksContextKey, locales)
+}
+
+// FallbacksFromContext get fallback locales from ctx
+func FallbacksFromContext(ctx context.Context) []string {
+	if ctx != nil {
+		if locales, ok := ctx.Value(fallbacksContextKey).([]string); ok {
+			return locales
+		}
+	}
+	return nil
+}
+
+// WithScope returns a copy of ctx that carries translation scope
+func WithScope(ctx context.Context, scope string) context.Context {
+	return context.WithValue(ctx, scopeContextKey, scope)
+}
+
+// ScopeFromContext get translation scope from ctx
+func ScopeFromContext(ctx context.Context) string {
+	if ctx != nil {
+		if scope, ok := ctx.Value(scopeContextKey).(string); ok {
+			return scope
+		}
+	}
+	return ""
+}
+
+// WithDefault returns a copy of ctx that carries default value for missing translations
+func WithDefault(ctx context.Context, value string) context.Context {
+	return context.WithValue(ctx, defaultValueContextKey, value)
+}
+
+// DefaultFromContext get default value for missing translations from ctx
+func DefaultFromContext(ctx context.Context) string {
+	if ctx != nil {
+		if value, ok := ctx.Value(defaultValueContextKey).(string); ok {
+			return value
+		}
+	}
+	return ""
+}
+
+// TContext translate key with locale, fallbacks, scope and default value from ctx
+func (i18n *I18n) TContext(ctx context.Context, key string, args ...interface{}) template.HTML {
+	var translator = i18n
+
+	if scope := ScopeFromContext(ctx); scope != "" {
+		translator = translator.Scope(scope).(*I18n)
+	}
+
+	if value := DefaultFromContext(ctx); value != "" {
+		translator = translator.Default(value).(*I18n)
+	}
+
+	if locales := FallbacksFromContext(ctx); len(locales) > 0 {
+		translator = translator.Fallbacks(append(append([]string{}, translator.fallbackLocales...), locales...)...).(*I18n)
+	}
+
+	return translator.T(LocaleFromContext(ctx), key, args...)
+}
+
+// MiddlewareConfig middleware config
+type MiddlewareConfig struct {
+	// Locales available locales, negotiated locale will be limited to them if set
+	Locales []string
+	// QueryParam query param name used to set locale, default is `locale`
+	QueryParam string
+	// CookieName cookie name used to set locale, default is `locale`
+	CookieName string
+	// URLPrefix get locale from URL prefix, e.g: `/zh-CN/products`, the prefix will be stripped from request path, only works with `Locales`
+	URLPrefix bool
+	// Negotiator negotiator used to match `Accept-Language` header, default is negotiator of `Locales`
+	Negotiator *Negotiator
+}
+
+// Middleware negotiate locale from URL prefix, query param, cookie or `Accept-Language` header, and save it into request's context
+func Middleware(config *MiddlewareConfig) func(http.Handler) http.Handler {
+	// copy config, so defaults won't be written into caller's config
+	if config == nil {
+		config = &MiddlewareConfig{}
+	} else {
+		copied := *config
+		copied.Locales = append([]string{}, config.Locales...)
+		config = &copied
+	}
+
+	if config.QueryParam == "" {
+		config.QueryParam = "locale"
+	}
+
+	if config.CookieName == "" {
+		config.CookieName = "locale"
+	}
+
+	if config.Negotiator == nil && len(config.Locales) > 0 {
+		config.Negotiator = NewNegotiator(config.Locales...)
+	}
+
+	return func(handler http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+			var locale string
+
+			if config.URLPrefix && len(config.Locales) > 0 {
+				paths := strings.SplitN(strings.TrimPrefix(req.URL.Path, "/"), "/", 2)
+				if locale = config.match(paths[0]); locale != "" {
+					if len(paths) > 1 {
+						req.URL.Path = "/" + paths[1]
+					} else {
+						req.URL.Path = "/"
+					}
+				}
+			}
+
+			if locale == "" {
+				locale = config.match(req.URL.Query().Get(config.QueryParam))
+			}
+
+			if locale == "" {
+				if cookie, err := req.Cookie(config.CookieName); err == nil {
+					locale = config.match(cookie.Value)
+				}
+			}
+
+			if acceptLanguage := req.Header.Get("Accept-Language"); locale == "" && acceptLanguage != "" {
+				if config.Negotiator != nil {
+					if value, confidence := config.Negotiator.MatchAcceptLanguage(acceptLanguage); confidence != language.No {
+						locale = value
+					}
+				} else if tags, _, _ := language.ParseAcceptLanguage(acceptLanguage); len(tags) > 0 {
+					locale = tags[0].String()
+				}
+			}
+
+			if locale != "" {
+				req = req.WithContext(WithLocale(req.Context(), locale))
+			}
+
+			handler.ServeHTTP(w, req)
+		})
+	}
+}
+
+// match return matched available locale, or blank string if locale is not available
+func (config *MiddlewareConfig) match(locale string) string {
+	if locale == "" || len(config.Locales) == 0 {
+		return locale
+	}
+
+	for _, l := range config.Locales {
+		if strings.EqualFold(l, locale) {
+			return l
+		}
+	}
+	return ""
+}
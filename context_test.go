@@ -0,0 +1,83 @@
+package i18n
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestTContext(t *testing.T) {
+	i18n := New(&backend{})
+	i18n.AddTranslation(&Translation{Key: "hello-world", Locale: "zh-CN", Value: "你好世界"})
+	i18n.AddTranslation(&Translation{Key: "hello-world", Locale: "en-GB", Value: "Hello World"})
+
+	ctx := WithLocale(context.Background(), "zh-CN")
+	if value := i18n.TContext(ctx, "hello-world"); value != "你好世界" {
+		t.Errorf("should translate with locale from context, but got %v", value)
+	}
+
+	ctx = WithFallbacks(WithLocale(context.Background(), "fr-FR"), "en-GB")
+	if value := i18n.TContext(ctx, "hello-world"); value != "Hello World" {
+		t.Errorf("should fallback to locale from context, but got %v", value)
+	}
+
+	ctx = WithDefault(WithLocale(context.Background(), "fr-FR"), "Default Value")
+	if value := i18n.TContext(ctx, "non-existing-key"); value != "Default Value" {
+		t.Errorf("should use default value from context, but got %v", value)
+	}
+
+	if locale := LocaleFromContext(context.Background()); locale != Default {
+		t.Errorf("should return default locale if not set, but got %v", locale)
+	}
+}
+
+type middlewareTestCase struct {
+	URL            string
+	Cookie         string
+	AcceptLanguage string
+	ExpectLocale   string
+	ExpectPath     string
+}
+
+func TestMiddleware(t *testing.T) {
+	testCases := []middlewareTestCase{
+		{URL: "/zh-CN/products", ExpectLocale: "zh-CN", ExpectPath: "/products"},
+		{URL: "/products?locale=ja-JP", ExpectLocale: "ja-JP", ExpectPath: "/products"},
+		{URL: "/products?locale=fr-FR", ExpectLocale: Default, ExpectPath: "/products"},
+		{URL: "/products", Cookie: "zh-cn", ExpectLocale: "zh-CN", ExpectPath: "/products"},
+		{URL: "/products", AcceptLanguage: "fr-FR, ja-JP;q=0.8, zh-CN;q=0.9", ExpectLocale: "zh-CN", ExpectPath: "/products"},
+		{URL: "/products", AcceptLanguage: "fr-FR", ExpectLocale: Default, ExpectPath: "/products"},
+		{URL: "/products", AcceptLanguage: "zh-Hans;q=0.9, fr-FR", ExpectLocale: "zh-CN", ExpectPath: "/products"},
+	}
+
+	for i, testCase := range testCases {
+		var locale, path string
+		handler := Middleware(&MiddlewareConfig{Locales: []string{"en-US", "zh-CN", "ja-JP"}, URLPrefix: true})(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+			locale = LocaleFromContext(req.Context())
+			path = req.URL.Path
+		}))
+
+		req := httptest.NewRequest("GET", testCase.URL, nil)
+		if testCase.Cookie != "" {
+			req.AddCookie(&http.Cookie{Name: "locale", Value: testCase.Cookie})
+		}
+		if testCase.AcceptLanguage != "" {
+			req.Header.Set("Accept-Language", testCase.AcceptLanguage)
+		}
+		handler.ServeHTTP(httptest.NewRecorder(), req)
+
+		if locale != testCase.ExpectLocale || path != testCase.ExpectPath {
+			t.Errorf("#%d: expect locale %v, path %v, but got %v, %v", i+1, testCase.ExpectLocale, testCase.ExpectPath, locale, path)
+		}
+	}
+}
+
+func TestMiddlewareConfigNotChanged(t *testing.T) {
+	config := &MiddlewareConfig{Locales: []string{"en-US", "zh-CN"}}
+	Middleware(config)
+
+	if config.QueryParam != "" || config.CookieName != "" || config.Negotiator != nil {
+		t.Errorf("middleware shouldn't write defaults into config, but got %+v", config)
+	}
+}
@@ -1,6 +1,9 @@
 package i18n
 
 import (
+	"fmt"
+	"time"
+
 	"github.com/qor/admin"
 	"github.com/qor/qor/utils"
 )
@@ -15,7 +18,29 @@ func (controller *i18nController) Index(context *admin.Context) {
 
 func (controller *i18nController) Update(context *admin.Context) {
 	form := context.Request.Form
-	translation := Translation{Key: form.Get("Key"), Locale: form.Get("Locale"), Value: utils.HTMLSanitizer.Sanitize(form.Get("Value"))}
+	translation := Translation{Key: form.Get("Key"), Context: form.Get("Context"), Locale: form.Get("Locale"), Value: utils.HTMLSanitizer.Sanitize(form.Get("Value"))}
+
+	if !controller.I18n.Context(translation.Context).Editable(translation.Locale, translation.Key) {
+		context.Writer.WriteHeader(422)
+		context.Writer.Write([]byte("translation is read only"))
+		return
+	}
+
+	var meta Meta
+	if result, ok := controller.I18n.Context(translation.Context).Lookup(translation.Locale, translation.Key); ok && result.Meta != nil {
+		if result.Meta.Exceeded(translation.Value) {
+			context.Writer.WriteHeader(422)
+			context.Writer.Write([]byte(fmt.Sprintf("translation is longer than %v characters", result.Meta.MaxLength)))
+			return
+		}
+		meta = *result.Meta
+	}
+
+	meta.UpdatedAt, meta.UpdatedBy, meta.Origin = time.Now(), "", OriginAdmin
+	if context.CurrentUser != nil {
+		meta.UpdatedBy = context.CurrentUser.DisplayName()
+	}
+	translation.Meta = &meta
 
 	if err := controller.I18n.SaveTranslation(&translation); err == nil {
 		context.Writer.Write([]byte("OK"))
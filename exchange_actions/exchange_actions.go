@@ -73,8 +73,8 @@ func RegisterExchangeJobs(I18n *i18n.I18n, Worker *worker.Worker) {
 
 			writer := csv.NewWriter(csvfile)
 
-			// Append Headers
-			writer.Write(append([]string{"Translation Keys"}, locales...))
+			// Append Headers, message context of translations is exported in a separate column
+			writer.Write(append([]string{"Translation Keys", "Context"}, locales...))
 
 			// Sort translation keys
 			for _, locale := range locales {
@@ -86,7 +86,14 @@ func RegisterExchangeJobs(I18n *i18n.I18n, Worker *worker.Worker) {
 			for key := range translationsMap {
 				translationKeys = append(translationKeys, key)
 			}
-			sort.Strings(translationKeys)
+			sort.Slice(translationKeys, func(i, j int) bool {
+				contextI, keyI := i18n.SplitMessageKey(translationKeys[i])
+				contextJ, keyJ := i18n.SplitMessageKey(translationKeys[j])
+				if keyI != keyJ {
+					return keyI < keyJ
+				}
+				return contextI < contextJ
+			})
 
 			// Write CSV file
 			var (
@@ -99,13 +106,14 @@ func RegisterExchangeJobs(I18n *i18n.I18n, Worker *worker.Worker) {
 			for _, translationKey := range translationKeys {
 				// Filter out translation by scope
 				index++
-				if scope == "Backend" && !strings.HasPrefix(translationKey, "qor_") {
+				msgctxt, key := i18n.SplitMessageKey(translationKey)
+				if scope == "Backend" && !strings.HasPrefix(key, "qor_") {
 					continue
 				}
-				if scope == "Frontend" && strings.HasPrefix(translationKey, "qor_") {
+				if scope == "Frontend" && strings.HasPrefix(key, "qor_") {
 					continue
 				}
-				var translations = []string{translationKey}
+				var translations = []string{key, msgctxt}
 				for _, locale := range locales {
 					var value string
 					if translation := i18nTranslations[locale][translationKey]; translation != nil {
@@ -149,36 +157,67 @@ func RegisterExchangeJobs(I18n *i18n.I18n, Worker *worker.Worker) {
 							perCount            = recordCount/20 + 1
 							processedRecordLogs = []string{}
 							locales             = records[0][1:]
+							hasContext          = records[0][1] == "Context"
 							index               = 1
+							translations        []*i18n.Translation
 						)
+
+						// save translations in batches, backends that implement `i18n.BatchWriter` save a batch at once
+						var saveTranslations = func() {
+							if len(translations) > 0 {
+								if err := I18n.SaveTranslations(translations); err != nil {
+									qorJob.AddLog(fmt.Sprintf("Failed to import translations, got %v\n", err))
+								}
+								translations = nil
+							}
+						}
+
+						if hasContext {
+							locales = records[0][2:]
+						}
+
 						for _, values := range records[1:] {
-							logMsg := ""
-							for idx, value := range values[1:] {
+							var (
+								logMsg = ""
+								// files exported before the Context column was added have keys like `i18n.MessageKey(context, key)`
+								msgctxt, key = i18n.SplitMessageKey(values[0])
+								columns      = values[1:]
+							)
+							if hasContext {
+								msgctxt, key, columns = values[1], values[0], values[2:]
+							}
+							for idx, value := range columns {
 								if value == "" {
 									if values[0] != "" && locales[idx] != "" {
 										I18n.DeleteTranslation(&i18n.Translation{
-											Key:    values[0],
-											Locale: locales[idx],
+											Key:     key,
+											Context: msgctxt,
+											Locale:  locales[idx],
+											Meta:    &i18n.Meta{Origin: i18n.OriginImport},
 										})
 										logMsg += fmt.Sprintf("%v/%v Deleted %v,%v\n", index, recordCount, locales[idx], values[0])
 									}
 								} else {
-									I18n.SaveTranslation(&i18n.Translation{
-										Key:    values[0],
-										Locale: locales[idx],
-										Value:  value,
+									translations = append(translations, &i18n.Translation{
+										Key:     key,
+										Context: msgctxt,
+										Locale:  locales[idx],
+										Value:   value,
+										Meta:    &i18n.Meta{Origin: i18n.OriginImport},
 									})
 									logMsg += fmt.Sprintf("%v/%v Imported %v,%v,%v\n", index, recordCount, locales[idx], values[0], value)
 								}
 							}
 							processedRecordLogs = append(processedRecordLogs, logMsg)
 							if len(processedRecordLogs) == perCount {
+								saveTranslations()
 								qorJob.AddLog(strings.Join(processedRecordLogs, ""))
 								processedRecordLogs = []string{}
 								qorJob.SetProgress(uint(float32(index) / float32(recordCount+1) * 100))
 							}
 							index++
 						}
+						saveTranslations()
 						qorJob.AddLog(strings.Join(processedRecordLogs, ""))
 					}
 				}
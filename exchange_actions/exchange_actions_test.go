@@ -100,6 +100,11 @@ func TestImportTranslations(t *testing.T) {
 			ImportFile:     "import_3.csv",
 			ExpectZhValues: map[string]string{"qor_admin.title": "标题", "qor_admin.subtitle": "小标题", "qor_admin.description": "描述", "header.title": "标题"},
 		},
+		&testImportTranslationsCase{
+			ImportFileDesc: "Translation file with context column",
+			ImportFile:     "import_4.csv",
+			ExpectZhValues: map[string]string{"qor_admin.title": "标题", "qor_admin.subtitle": "小标题", "qor_admin.description": "描述", "header.title": "标题", i18n.MessageKey("menu", "header.title"): "菜单标题"},
+		},
 	}
 
 	for i, testCase := range testCases {
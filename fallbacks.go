@@ -0,0 +1,54 @@
+package i18n
+
+import (
+	"strings"
+	"sync"
+	"sync/atomic"
+
+	"golang.org/x/text/language"
+)
+
+// parentLocalesCache cache parent locales of canonical locales, it is bounded by maxParentLocalesCache as locales might come from user input
+var (
+	parentLocalesCache     sync.Map
+	parentLocalesCacheSize int64
+)
+
+const maxParentLocalesCache = 1024
+
+// ParentLocales return parent locales of locale, derived from CLDR parent locale data and locale tag structure, e.g:
+//
+//	fr-CA      => fr
+//	en-AU      => en-001, en
+//	zh-Hant-HK => zh-Hant, zh
+func ParentLocales(locale string) []string {
+	if parents, ok := parentLocalesCache.Load(locale); ok {
+		return parents.([]string)
+	}
+
+	var parents []string
+	for parent := parentLocale(locale); parent != ""; parent = parentLocale(parent) {
+		parents = append(parents, parent)
+	}
+
+	if tag, err := language.Parse(locale); err == nil && tag.String() == locale && atomic.LoadInt64(&parentLocalesCacheSize) < maxParentLocalesCache {
+		if _, loaded := parentLocalesCache.LoadOrStore(locale, parents); !loaded {
+			atomic.AddInt64(&parentLocalesCacheSize, 1)
+		}
+	}
+	return parents
+}
+
+func parentLocale(locale string) string {
+	if tag, err := language.Parse(locale); err == nil {
+		if parent := tag.Parent(); !parent.IsRoot() {
+			return parent.String()
+		}
+	}
+
+	// CLDR data doesn't have parent for the locale, e.g: `zh-Hant`'s parent is root, remove last subtag then
+	if idx := strings.LastIndex(locale, "-"); idx > 0 {
+		return locale[:idx]
+	}
+	return ""
+}
@@ -0,0 +1,65 @@
+package i18n
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParentLocales(t *testing.T) {
+	testCases := map[string][]string{
+		"fr-CA":      {"fr"},
+		"en-AU":      {"en-001", "en"},
+		"zh-Hant-HK": {"zh-Hant", "zh"},
+		"en":         nil,
+	}
+
+	for locale, expected := range testCases {
+		if parents := ParentLocales(locale); !reflect.DeepEqual(parents, expected) {
+			t.Errorf("parent locales of %v should be %v, but got %v", locale, expected, parents)
+		}
+	}
+}
+
+func TestParentFallbacks(t *testing.T) {
+	i18n := New(&backend{})
+	i18n.AddTranslation(&Translation{Key: "hello-world", Locale: "fr", Value: "Bonjour le monde"})
+	i18n.AddTranslation(&Translation{Key: "colour", Locale: "en-001", Value: "Colour"})
+
+	if value := i18n.T("fr-CA", "hello-world"); value != "hello-world" {
+		t.Errorf("shouldn't fallback to parent locales if not enabled, but got %v", value)
+	}
+
+	i18n.ParentFallbacks = true
+	if value := i18n.T("fr-CA", "hello-world"); value != "Bonjour le monde" {
+		t.Errorf("should fallback fr-CA to fr, but got %v", value)
+	}
+
+	if value := i18n.Scope("").T("en-AU", "colour"); value != "Colour" {
+		t.Errorf("should fallback en-AU to en-001, but got %v", value)
+	}
+
+	i18n.AddTranslation(&Translation{Key: "color", Locale: "zh-Hant", Value: "顏色 (zh-Hant)"})
+	if value := i18n.T("zh-HK", "color"); value != "顏色 (zh-Hant)" {
+		t.Errorf("should fallback zh-HK to zh-Hant, but got %v", value)
+	}
+
+	i18n.AddTranslation(&Translation{Key: "color", Locale: "zh-TW", Value: "顏色 (zh-TW)"})
+	i18n.FallbackLocales = map[string][]string{"zh-HK": {"zh-TW"}}
+	if value := i18n.T("zh-HK", "color"); value != "顏色 (zh-TW)" {
+		t.Errorf("configured fallback locales should be used before parent locales, but got %v", value)
+	}
+}
+
+func TestParentLocalesCache(t *testing.T) {
+	ParentLocales("fr-CA")
+	if _, ok := parentLocalesCache.Load("fr-CA"); !ok {
+		t.Errorf("parent locales of canonical locale should be cached")
+	}
+
+	for _, locale := range []string{"FR-ca", "not a locale", "xx-random-input-123"} {
+		ParentLocales(locale)
+		if _, ok := parentLocalesCache.Load(locale); ok {
+			t.Errorf("parent locales of %v shouldn't be cached", locale)
+		}
+	}
+}
@@ -0,0 +1,166 @@
+package i18n
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"text/template/parse"
+
+	"github.com/theplant/cldr"
+)
+
+// Formatter format translation value with arguments
+type Formatter interface {
+	Format(locale, value string, args ...interface{}) (string, error)
+}
+
+// FormatterFunc is an adapter to allow the use of ordinary functions as formatter
+type FormatterFunc func(locale, value string, args ...interface{}) (string, error)
+
+// Format call f(locale, value, args...)
+func (f FormatterFunc) Format(locale, value string, args ...interface{}) (string, error) {
+	return f(locale, value, args...)
+}
+
+// CLDRFormatter format translation with [cldr](https://github.com/theplant/cldr) (`p`, `zero`, `one`, `two`, `few`, `many`, `other`), it is the default formatter,
+// `ordinal`, `select` and `gender` of TemplateFormatter are also available, e.g: `{{gender .Gender (male "Il") (female "Elle") (other "Iel")}}`
+type CLDRFormatter struct{}
+
+// Format format translation with cldr syntax
+func (formatter CLDRFormatter) Format(locale, value string, args ...interface{}) (string, error) {
+	tmpl, err := formatter.Compile(locale, value)
+	if err != nil {
+		return value, err
+	}
+	return tmpl.Execute(args...)
+}
+
+// cldrExtensions functions of TemplateFormatter that are available in CLDRFormatter
+var cldrExtensions = map[string]bool{"ordinal": true, "select": true, "gender": true}
+
+// Compile values without template actions won't be parsed, values use `ordinal`, `select` or `gender` are compiled into golang template like TemplateFormatter, others are formatted by cldr
+func (CLDRFormatter) Compile(locale, value string) (CompiledTemplate, error) {
+	if !strings.Contains(value, "{{") {
+		return staticTemplate(value), nil
+	}
+
+	if usesFunctions(value, cldrExtensions) {
+		return compileTemplate(locale, value, false)
+	}
+	return &formatterTemplate{formatter: FormatterFunc(cldr.Parse), locale: locale, value: value}, nil
+}
+
+// usesFunctions check value calls any of functions, nested templates of plural forms and cases are checked as well
+func usesFunctions(value string, funcs map[string]bool) bool {
+	tree := parse.New("")
+	tree.Mode = parse.SkipFuncCheck
+	if _, err := tree.Parse(value, "", "", map[string]*parse.Tree{}); err != nil {
+		return false
+	}
+
+	var walk func(node parse.Node) bool
+	walk = func(node parse.Node) bool {
+		switch n := node.(type) {
+		case *parse.ListNode:
+			if n != nil {
+				for _, child := range n.Nodes {
+					if walk(child) {
+						return true
+					}
+				}
+			}
+		case *parse.ActionNode:
+			return walk(n.Pipe)
+		case *parse.IfNode:
+			return walk(n.Pipe) || walk(n.List) || walk(n.ElseList)
+		case *parse.RangeNode:
+			return walk(n.Pipe) || walk(n.List) || walk(n.ElseList)
+		case *parse.WithNode:
+			return walk(n.Pipe) || walk(n.List) || walk(n.ElseList)
+		case *parse.PipeNode:
+			if n != nil {
+				for _, cmd := range n.Cmds {
+					if walk(cmd) {
+						return true
+					}
+				}
+			}
+		case *parse.CommandNode:
+			for _, arg := range n.Args {
+				if walk(arg) {
+					return true
+				}
+			}
+		case *parse.IdentifierNode:
+			return funcs[n.Ident]
+		case *parse.StringNode:
+			return strings.Contains(n.Text, "{{") && usesFunctions(n.Text, funcs)
+		}
+		return false
+	}
+	return walk(tree.Root)
+}
+
+// SprintfFormatter format translation with fmt.Sprintf, e.g: `Hello %s`
+type SprintfFormatter struct{}
+
+// Format format translation with fmt.Sprintf, return error if there are bad verbs or mismatched arguments
+func (SprintfFormatter) Format(locale, value string, args ...interface{}) (string, error) {
+	if len(args) == 0 {
+		return value, nil
+	}
+
+	str := fmt.Sprintf(value, args...)
+	if strings.Contains(str, "%!") {
+		return value, errors.New("failed to format translation: " + str)
+	}
+	return str, nil
+}
+
+// NoopFormatter return translation value as it is
+type NoopFormatter struct{}
+
+// Format return value without formatting
+func (NoopFormatter) Format(locale, value string, args ...interface{}) (string, error) {
+	return value, nil
+}
+
+// DetectFormatter choose formatter for each translation by its value, golang template (contains `{{`) will be formatted with Template, and ICU MessageFormat (contains `{`) will be formatted with ICU
+type DetectFormatter struct {
+	Template Formatter
+	ICU      Formatter
+}
+
+// Format format translation with detected formatter
+func (formatter DetectFormatter) Format(locale, value string, args ...interface{}) (string, error) {
+	tmpl, err := formatter.Compile(locale, value)
+	if err != nil {
+		return value, err
+	}
+	return tmpl.Execute(args...)
+}
+
+// Compile compile translation with detected formatter
+func (formatter DetectFormatter) Compile(locale, value string) (CompiledTemplate, error) {
+	var f Formatter = CLDRFormatter{}
+	if !strings.Contains(value, "{{") && strings.Contains(value, "{") {
+		f = ICUFormatter{}
+		if formatter.ICU != nil {
+			f = formatter.ICU
+		}
+	} else if formatter.Template != nil {
+		f = formatter.Template
+	}
+
+	if compiler, ok := f.(Compiler); ok {
+		return compiler.Compile(locale, value)
+	}
+	return &formatterTemplate{formatter: f, locale: locale, value: value}, nil
+}
+
+func (i18n *I18n) getFormatter() Formatter {
+	if i18n.Formatter != nil {
+		return i18n.Formatter
+	}
+	return CLDRFormatter{}
+}
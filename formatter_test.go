@@ -0,0 +1,88 @@
+package i18n
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestFormatters(t *testing.T) {
+	if value, err := (SprintfFormatter{}).Format("en-US", "Hello %s, you have %d messages", "Jinzhu", 2); err != nil || value != "Hello Jinzhu, you have 2 messages" {
+		t.Errorf("should format with fmt, but got %v (%v)", value, err)
+	}
+
+	badVerb := "Hello %d"
+	if _, err := (SprintfFormatter{}).Format("en-US", badVerb, "Jinzhu"); err == nil {
+		t.Errorf("should return error for bad verb")
+	}
+
+	if value, err := (NoopFormatter{}).Format("en-US", "Hello {{$1}}", "Jinzhu"); err != nil || value != "Hello {{$1}}" {
+		t.Errorf("should return value as it is, but got %v (%v)", value, err)
+	}
+}
+
+func TestTE(t *testing.T) {
+	i18n := New(&backend{})
+	i18n.AddTranslation(&Translation{Key: "broken", Locale: "en-US", Value: "Hello {{.Name"})
+	i18n.AddTranslation(&Translation{Key: "hello", Locale: "en-US", Value: "Hello {{$1}}"})
+
+	if value, err := i18n.TE("en-US", "broken"); err == nil || value != "Hello {{.Name" {
+		t.Errorf("should return error and unformatted value for broken template, but got %v (%v)", value, err)
+	}
+
+	if value := i18n.T("en-US", "broken"); value != "Hello {{.Name" {
+		t.Errorf("T should return unformatted value for broken template, but got %v", value)
+	}
+
+	if value, err := i18n.TE("en-US", "hello", "Jinzhu"); err != nil || value != "Hello Jinzhu" {
+		t.Errorf("should format translation, but got %v (%v)", value, err)
+	}
+}
+
+func TestCLDRFormatter(t *testing.T) {
+	tmpl, err := CLDRFormatter{}.Compile("en-US", `{{$1}} has {{p "Count" (one "{{.Count}} item") (other "{{.Count}} items")}}`)
+	if err != nil {
+		t.Fatalf("failed to compile translation, got %v", err)
+	}
+
+	for count, expected := range map[int]string{1: "1 item", 2: "2 items"} {
+		if value, err := tmpl.Execute(map[string]int{"Count": count}); err != nil || value != "map[Count:"+fmt.Sprint(count)+"] has "+expected {
+			t.Errorf("should format plural with compiled template, but got %v (%v)", value, err)
+		}
+	}
+
+	if _, err := (CLDRFormatter{}).Format("en-US", `{{number .Count}}`, map[string]int{"Count": 1}); err == nil {
+		t.Errorf("helpers of TemplateFormatter should not be available in CLDRFormatter")
+	}
+
+	for value, expected := range map[string]bool{
+		`{{p "Count" (one "{{.Count}} item") (other "{{.Count}} items")}}`:                     false,
+		`{{if .Admin}}{{select .Role (when "admin" "Administrator") (other "User")}}{{end}}`:   true,
+		`{{p "Count" (one "{{gender .Gender (male \"Il\") (other \"Iel\")}}") (other "Ils")}}`: true,
+		`Hello {{.Name}}`: false,
+	} {
+		if usesFunctions(value, cldrExtensions) != expected {
+			t.Errorf("%v should be formatted by cldr: %v", value, !expected)
+		}
+	}
+}
+
+func TestCLDRFormatterSelectAndOrdinal(t *testing.T) {
+	for _, c := range []struct {
+		Locale string
+		Value  string
+		Arg    interface{}
+		Expect string
+	}{
+		{"fr-FR", `{{gender .Gender (male "Il") (female "Elle") (other "Iel")}}`, map[string]string{"Gender": "female"}, "Elle"},
+		{"en-US", `{{select .Role (when "admin" "Administrator") (other "User")}}`, map[string]string{"Role": "editor"}, "User"},
+		{"en-US", `{{ordinal .Rank (one "{{.Rank}}st") (two "{{.Rank}}nd") (few "{{.Rank}}rd") (other "{{.Rank}}th")}}`, map[string]int{"Rank": 22}, "22nd"},
+	} {
+		if value, err := (CLDRFormatter{}).Format(c.Locale, c.Value, c.Arg); err != nil || value != c.Expect {
+			t.Errorf("%v: should format %v, but got %v (%v)", c.Locale, c.Expect, value, err)
+		}
+	}
+
+	if _, err := (CLDRFormatter{}).Compile("en-US", `{{select .Role (when "admin" "Administrator")}}`); err == nil {
+		t.Errorf("select without other case should be invalid")
+	}
+}
@@ -0,0 +1,183 @@
+package i18n
+
+import (
+	"expvar"
+	"fmt"
+	"runtime"
+	"sort"
+	"strings"
+	"sync"
+	"sync/atomic"
+)
+
+// MissingEvent event of missing translation
+type MissingEvent struct {
+	Locale string
+	Key    string
+	Scope  string
+	// Caller file and line that called T, e.g: `app/views.go:12`
+	Caller string
+}
+
+// FallbackEvent event of translation resolved from fallback locale
+type FallbackEvent struct {
+	RequestedLocale string
+	ResolvedLocale  string
+	Key             string
+	Scope           string
+	// Caller file and line that called T, e.g: `app/views.go:12`
+	Caller string
+}
+
+// Metric translation metric, e.g: `{Name: "i18n_missing_total", Labels: {"locale": "zh-CN"}, Value: 12}`
+type Metric struct {
+	Name   string
+	Labels map[string]string
+	Value  float64
+}
+
+type hooks struct {
+	mutex      sync.RWMutex
+	onMissing  []func(MissingEvent)
+	onFallback []func(FallbackEvent)
+	counters   sync.Map
+}
+
+type counterKey struct {
+	name            string
+	locale          string
+	requestedLocale string
+}
+
+// OnMissing register callback that will be called when translation is missing in T
+func (i18n *I18n) OnMissing(fc func(MissingEvent)) {
+	i18n.hooks.mutex.Lock()
+	i18n.hooks.onMissing = append(i18n.hooks.onMissing, fc)
+	i18n.hooks.mutex.Unlock()
+}
+
+// OnFallback register callback that will be called when translation is resolved from fallback locale in T
+func (i18n *I18n) OnFallback(fc func(FallbackEvent)) {
+	i18n.hooks.mutex.Lock()
+	i18n.hooks.onFallback = append(i18n.hooks.onFallback, fc)
+	i18n.hooks.mutex.Unlock()
+}
+
+// triggerHooks update counters and call registered callbacks for lookup result of T
+func (i18n *I18n) triggerHooks(key string, result Result, found bool) {
+	if i18n.hooks == nil {
+		return
+	}
+
+	requestedLocale := i18n.metricLocale(result.RequestedLocale)
+	i18n.hooks.inc(counterKey{name: "i18n_translations_total", locale: requestedLocale})
+
+	if !found {
+		i18n.hooks.inc(counterKey{name: "i18n_missing_total", locale: requestedLocale})
+
+		i18n.hooks.mutex.RLock()
+		callbacks := i18n.hooks.onMissing
+		i18n.hooks.mutex.RUnlock()
+
+		if len(callbacks) > 0 {
+			event := MissingEvent{Locale: result.RequestedLocale, Key: key, Scope: i18n.scope, Caller: caller()}
+			for _, fc := range callbacks {
+				fc(event)
+			}
+		}
+	} else if result.Fallback {
+		i18n.hooks.inc(counterKey{name: "i18n_fallbacks_total", locale: i18n.metricLocale(result.Locale), requestedLocale: requestedLocale})
+
+		i18n.hooks.mutex.RLock()
+		callbacks := i18n.hooks.onFallback
+		i18n.hooks.mutex.RUnlock()
+
+		if len(callbacks) > 0 {
+			event := FallbackEvent{RequestedLocale: result.RequestedLocale, ResolvedLocale: result.Locale, Key: key, Scope: i18n.scope, Caller: caller()}
+			for _, fc := range callbacks {
+				fc(event)
+			}
+		}
+	}
+}
+
+// OtherLocale metrics label of locales that have no translations and aren't configured in FallbackLocales, to keep cardinality of metrics bounded
+const OtherLocale = "other"
+
+// metricLocale return locale used as metrics label, unknown locales are labeled as OtherLocale
+func (i18n *I18n) metricLocale(locale string) string {
+	if locale == Default || i18n.snapshot.hasLocale(locale) {
+		return locale
+	}
+	if _, ok := i18n.FallbackLocales[locale]; ok {
+		return locale
+	}
+	return OtherLocale
+}
+
+func (h *hooks) inc(key counterKey) {
+	counter, ok := h.counters.Load(key)
+	if !ok {
+		counter, _ = h.counters.LoadOrStore(key, new(uint64))
+	}
+	atomic.AddUint64(counter.(*uint64), 1)
+}
+
+// Metrics return counters of translations, missing translations and fallbacks by locale, could be used to implement prometheus collector
+func (i18n *I18n) Metrics() (metrics []Metric) {
+	if i18n.hooks == nil {
+		return nil
+	}
+
+	i18n.hooks.counters.Range(func(key, value interface{}) bool {
+		k := key.(counterKey)
+		metric := Metric{Name: k.name, Labels: map[string]string{"locale": k.locale}, Value: float64(atomic.LoadUint64(value.(*uint64)))}
+		if k.requestedLocale != "" {
+			metric.Labels["requested_locale"] = k.requestedLocale
+		}
+		metrics = append(metrics, metric)
+		return true
+	})
+
+	sort.Slice(metrics, func(i, j int) bool {
+		if metrics[i].Name != metrics[j].Name {
+			return metrics[i].Name < metrics[j].Name
+		}
+		return fmt.Sprint(metrics[i].Labels) < fmt.Sprint(metrics[j].Labels)
+	})
+	return metrics
+}
+
+// ExpvarFunc return expvar func of metrics, e.g: `expvar.Publish("i18n", I18n.ExpvarFunc())`
+func (i18n *I18n) ExpvarFunc() expvar.Func {
+	return func() interface{} {
+		var results = map[string]map[string]float64{}
+		for _, metric := range i18n.Metrics() {
+			if results[metric.Name] == nil {
+				results[metric.Name] = map[string]float64{}
+			}
+
+			label := metric.Labels["locale"]
+			if requestedLocale := metric.Labels["requested_locale"]; requestedLocale != "" {
+				label = requestedLocale + "=>" + label
+			}
+			results[metric.Name][label] = metric.Value
+		}
+		return results
+	}
+}
+
+// caller return first caller outside of i18n package
+func caller() string {
+	pcs := make([]uintptr, 10)
+	frames := runtime.CallersFrames(pcs[:runtime.Callers(3, pcs)])
+	for {
+		frame, more := frames.Next()
+		if !strings.HasPrefix(frame.Function, "github.com/qor/i18n.") {
+			return fmt.Sprintf("%v:%v", frame.File, frame.Line)
+		}
+		if !more {
+			return ""
+		}
+	}
+}
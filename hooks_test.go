@@ -0,0 +1,56 @@
+package i18n
+
+import "testing"
+
+func TestHooks(t *testing.T) {
+	i18n := New(&backend{})
+	i18n.AddTranslation(&Translation{Key: "hello-world", Locale: "en-US", Value: "Hello World"})
+
+	var (
+		missingEvents  []MissingEvent
+		fallbackEvents []FallbackEvent
+	)
+	i18n.OnMissing(func(event MissingEvent) { missingEvents = append(missingEvents, event) })
+	i18n.OnFallback(func(event FallbackEvent) { fallbackEvents = append(fallbackEvents, event) })
+
+	i18n.T("en-US", "hello-world")
+	i18n.T("zh-CN", "hello-world")
+	i18n.Scope("home").T("zh-CN", "missing")
+
+	if len(fallbackEvents) != 1 || fallbackEvents[0].RequestedLocale != "zh-CN" || fallbackEvents[0].ResolvedLocale != "en-US" {
+		t.Errorf("should trigger fallback event, but got %#v", fallbackEvents)
+	}
+
+	if len(missingEvents) != 1 || missingEvents[0].Key != "missing" || missingEvents[0].Scope != "home" || missingEvents[0].Caller == "" {
+		t.Errorf("should trigger missing event, but got %#v", missingEvents)
+	}
+
+	var counts = map[string]float64{}
+	for _, metric := range i18n.Metrics() {
+		counts[metric.Name] += metric.Value
+	}
+
+	if counts["i18n_translations_total"] != 3 || counts["i18n_missing_total"] != 1 || counts["i18n_fallbacks_total"] != 1 {
+		t.Errorf("metrics are incorrect, got %v", counts)
+	}
+}
+
+func TestHooksMetricsUnknownLocales(t *testing.T) {
+	i18n := New(&backend{})
+	i18n.AddTranslation(&Translation{Key: "hello-world", Locale: "zh-CN", Value: "你好世界"})
+
+	i18n.T("zh-CN", "hello-world")
+	i18n.T("xx-1", "hello-world")
+	i18n.T("xx-2", "hello-world")
+
+	var locales = map[string]float64{}
+	for _, metric := range i18n.Metrics() {
+		if metric.Name == "i18n_translations_total" {
+			locales[metric.Labels["locale"]] += metric.Value
+		}
+	}
+
+	if len(locales) != 2 || locales["zh-CN"] != 1 || locales[OtherLocale] != 2 {
+		t.Errorf("unknown locales should be labeled as other, but got %v", locales)
+	}
+}
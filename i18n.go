@@ -1,15 +1,19 @@
 package i18n
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"html/template"
 	"io/ioutil"
+	"log"
 	"net/http"
 	"path/filepath"
 	"sort"
 	"strconv"
 	"strings"
+	"sync"
+	"time"
 
 	"github.com/qor/admin"
 	"github.com/qor/cache"
@@ -17,7 +21,6 @@ import (
 	"github.com/qor/qor"
 	"github.com/qor/qor/resource"
 	"github.com/qor/qor/utils"
-	"github.com/theplant/cldr"
 )
 
 // Default default locale for i18n
@@ -27,11 +30,30 @@ var Default = "en-US"
 type I18n struct {
 	Resource        *admin.Resource
 	scope           string
+	msgctxt         string
 	value           string
 	Backends        []Backend
 	FallbackLocales map[string][]string
-	fallbackLocales []string
-	cacheStore      cache.CacheStoreInterface
+	// ParentFallbacks fallback to parent locales derived from locale tag and CLDR data, e.g: `fr-CA` => `fr`, `en-AU` => `en-001` => `en`
+	ParentFallbacks bool
+	// Formatter formatter used to format translation values, default is CLDRFormatter
+	Formatter Formatter
+	// MissingPolicy how to handle missing translations in T, default is MissingAutoCreate
+	MissingPolicy MissingPolicy
+	// MissingFlushInterval flush recorded missing translations asynchronously after the interval, only works with MissingRecord
+	MissingFlushInterval time.Duration
+	fallbackLocales      []string
+	cacheStore           cache.CacheStoreInterface
+	missingTranslations  *missingTranslations
+	hooks                *hooks
+	compiledTranslations *sync.Map
+	snapshot             *snapshot
+	lazy                 bool
+	locales              *sync.Map
+	availableLocales     *availableLocales
+	notifier             Notifier
+	unsubscribe          func()
+	nodeID               string
 }
 
 // ResourceName change display name in qor admin
@@ -39,29 +61,35 @@ func (I18n) ResourceName() string {
 	return "Translation"
 }
 
-// Backend defined methods that needs for translation backend
+// Backend defined methods that needs for translation backend, backends could implement optional capabilities: Writer, Deleter, Finder, Searcher, Watcher
 type Backend interface {
-	LoadTranslations() []*Translation
-	SaveTranslation(*Translation) error
-	FindTranslation(*Translation) Translation
-	DeleteTranslation(*Translation) error
+	Lister
 }
 
-// Translation is a struct for translations, including Translation Key, Locale, Value
+// Translation is a struct for translations, including Translation Key, Locale, Value and optional metadata
 type Translation struct {
-	Key     string
-	Locale  string
-	Value   string
+	Key    string
+	Locale string
+	Value  string
+	// Context message context to disambiguate identical keys, like gettext's `msgctxt`, e.g: `button`, `menu`
+	Context string  `json:",omitempty"`
+	Meta    *Meta   `json:",omitempty" gorm:"-"`
 	Backend Backend `json:"-"`
 }
 
-// New initialize I18n with backends
+// New initialize I18n with backends, errors of loading translations are logged, use NewWithError to handle them
 func New(backends ...Backend) *I18n {
-	i18n := &I18n{Backends: backends, cacheStore: memory.New()}
-	i18n.loadToCacheStore()
+	i18n, err := NewWithError(backends...)
+	if err != nil {
+		log.Printf("i18n: failed to load translations, got %v", err)
+	}
 	return i18n
 }
 
+func newI18n(backends ...Backend) *I18n {
+	return &I18n{Backends: backends, cacheStore: memory.New(), missingTranslations: &missingTranslations{}, hooks: &hooks{}, compiledTranslations: &sync.Map{}, snapshot: &snapshot{}}
+}
+
 // SetCacheStore set i18n's cache store
 func (i18n *I18n) SetCacheStore(cacheStore cache.CacheStoreInterface) {
 	i18n.cacheStore = cacheStore
@@ -69,131 +97,113 @@ func (i18n *I18n) SetCacheStore(cacheStore cache.CacheStoreInterface) {
 }
 
 func (i18n *I18n) loadToCacheStore() {
-	backends := i18n.Backends
-	for i := len(backends) - 1; i >= 0; i-- {
-		var backend = backends[i]
-		for _, translation := range backend.LoadTranslations() {
-			i18n.AddTranslation(translation)
+	i18n.Reload()
+	for _, translations := range i18n.snapshot.load() {
+		for _, translation := range translations {
+			i18n.cacheStore.Set(cacheKey(translation.Locale, translation.messageKey()), translation)
 		}
 	}
 }
 
-// LoadTranslations load translations as map `map[locale]map[key]*Translation`
+// LoadTranslations load translations as map `map[locale]map[key]*Translation`, translations with message context are indexed with `MessageKey(context, key)`
 func (i18n *I18n) LoadTranslations() map[string]map[string]*Translation {
 	var translations = map[string]map[string]*Translation{}
 
-	for i := len(i18n.Backends); i > 0; i-- {
-		backend := i18n.Backends[i-1]
-		for _, translation := range backend.LoadTranslations() {
-			if translations[translation.Locale] == nil {
-				translations[translation.Locale] = map[string]*Translation{}
-			}
-			translations[translation.Locale][translation.Key] = translation
+	results, _ := mergeTranslations(context.Background(), i18n.Backends, nil)
+	for _, translation := range results {
+		if translations[translation.Locale] == nil {
+			translations[translation.Locale] = map[string]*Translation{}
 		}
+		translations[translation.Locale][translation.messageKey()] = translation
 	}
 	return translations
 }
 
 // AddTranslation add translation
 func (i18n *I18n) AddTranslation(translation *Translation) error {
-	return i18n.cacheStore.Set(cacheKey(translation.Locale, translation.Key), translation)
+	var cached = cachedTranslation{
+		Translation:  *translation,
+		BackendIndex: i18n.backendIndex(translation.Backend) + 1,
+	}
+
+	i18n.snapshot.set(cached)
+	i18n.invalidateCompiledTranslation(translation)
+	return i18n.cacheStore.Set(cacheKey(translation.Locale, translation.messageKey()), cached)
 }
 
-// SaveTranslation save translation
+// SaveTranslation save translation into first writable backend
 func (i18n *I18n) SaveTranslation(translation *Translation) error {
-	for _, backend := range i18n.Backends {
-		if backend.SaveTranslation(translation) == nil {
-			i18n.AddTranslation(translation)
-			return nil
-		}
-	}
-
-	return errors.New("failed to save translation")
+	return i18n.SaveTranslationContext(context.Background(), translation)
 }
 
-// DeleteTranslation delete translation
+// DeleteTranslation delete translation from all deletable backends
 func (i18n *I18n) DeleteTranslation(translation *Translation) (err error) {
-	for _, backend := range i18n.Backends {
-		backend.DeleteTranslation(translation)
-	}
+	return i18n.DeleteTranslationContext(context.Background(), translation)
+}
 
-	return i18n.cacheStore.Delete(cacheKey(translation.Locale, translation.Key))
+// removeTranslation remove translation from snapshot and cache store
+func (i18n *I18n) removeTranslation(translation *Translation) error {
+	i18n.snapshot.delete(translation.Locale, translation.messageKey())
+	i18n.invalidateCompiledTranslation(translation)
+	return i18n.cacheStore.Delete(cacheKey(translation.Locale, translation.messageKey()))
 }
 
 // Scope i18n scope
 func (i18n *I18n) Scope(scope string) admin.I18n {
-	return &I18n{cacheStore: i18n.cacheStore, scope: scope, value: i18n.value, Backends: i18n.Backends, Resource: i18n.Resource, FallbackLocales: i18n.FallbackLocales, fallbackLocales: i18n.fallbackLocales}
+	result := i18n.clone()
+	result.scope = scope
+	return result
 }
 
 // Default default value of translation if key is missing
 func (i18n *I18n) Default(value string) admin.I18n {
-	return &I18n{cacheStore: i18n.cacheStore, scope: i18n.scope, value: value, Backends: i18n.Backends, Resource: i18n.Resource, FallbackLocales: i18n.FallbackLocales, fallbackLocales: i18n.fallbackLocales}
+	result := i18n.clone()
+	result.value = value
+	return result
 }
 
 // Fallbacks fallback to locale if translation doesn't exist in specified locale
 func (i18n *I18n) Fallbacks(locale ...string) admin.I18n {
-	return &I18n{cacheStore: i18n.cacheStore, scope: i18n.scope, value: i18n.value, Backends: i18n.Backends, Resource: i18n.Resource, FallbackLocales: i18n.FallbackLocales, fallbackLocales: locale}
+	result := i18n.clone()
+	result.fallbackLocales = locale
+	return result
+}
+
+func (i18n *I18n) clone() *I18n {
+	result := *i18n
+	return &result
 }
 
 // T translate with locale, key and arguments
 func (i18n *I18n) T(locale, key string, args ...interface{}) template.HTML {
-	var (
-		value           = i18n.value
-		translationKey  = key
-		fallbackLocales = i18n.fallbackLocales
-	)
+	value, _ := i18n.TE(locale, key, args...)
+	return value
+}
 
+// TE translate with locale, key and arguments, return unformatted value and error if failed to format the translation
+func (i18n *I18n) TE(locale, key string, args ...interface{}) (template.HTML, error) {
 	if locale == "" {
 		locale = Default
 	}
 
-	if locales, ok := i18n.FallbackLocales[locale]; ok {
-		fallbackLocales = append(fallbackLocales, locales...)
+	var value = key
+	result, ok := i18n.Lookup(locale, key)
+	i18n.triggerHooks(key, result, ok)
+	if !ok {
+		// If not initialized
+		result.Value = i18n.handleMissingTranslation(&Translation{Key: result.Key, Context: result.Context, Value: result.Value, Locale: locale})
 	}
-	fallbackLocales = append(fallbackLocales, Default)
 
-	if i18n.scope != "" {
-		translationKey = strings.Join([]string{i18n.scope, key}, ".")
+	if result.Value != "" {
+		value = result.Value
 	}
 
-	var translation Translation
-	if err := i18n.cacheStore.Unmarshal(cacheKey(locale, key), &translation); err != nil || translation.Value == "" {
-		for _, fallbackLocale := range fallbackLocales {
-			if err := i18n.cacheStore.Unmarshal(cacheKey(fallbackLocale, key), &translation); err == nil && translation.Value != "" {
-				break
-			}
-		}
-
-		if translation.Value == "" {
-			// Get default translation if not translated
-			if err := i18n.cacheStore.Unmarshal(cacheKey(Default, key), &translation); err != nil || translation.Value == "" {
-				// If not initialized
-				var defaultBackend Backend
-				if len(i18n.Backends) > 0 {
-					defaultBackend = i18n.Backends[0]
-				}
-
-				translation = Translation{Key: translationKey, Value: value, Locale: locale, Backend: defaultBackend}
-				if t := defaultBackend.FindTranslation(&translation); t.Value != "" {
-					translation = t
-				} else {
-					i18n.SaveTranslation(&translation)
-				}
-			}
-		}
-	}
-
-	if translation.Value != "" {
-		value = translation.Value
-	} else {
-		value = key
+	str, err := i18n.format(locale, MessageKey(result.Context, result.Key), value, args...)
+	if err != nil {
+		return template.HTML(value), err
 	}
 
-	if str, err := cldr.Parse(locale, value, args...); err == nil {
-		value = str
-	}
-
-	return template.HTML(value)
+	return template.HTML(str), nil
 }
 
 // RenderInlineEditAssets render inline edit html, it is using: http://vitalets.github.io/x-editable/index.html
@@ -241,6 +251,10 @@ func getLocaleFromContext(context *qor.Context) string {
 		return locale
 	}
 
+	if context.Request != nil {
+		return LocaleFromContext(context.Request.Context())
+	}
+
 	return Default
 }
 
@@ -305,10 +319,13 @@ func (i18n *I18n) ConfigureQorResource(res resource.Resourcer) {
 
 		type matchedTranslation struct {
 			Key           string
+			Context       string
 			PrimaryLocale string
 			PrimaryValue  string
 			EditingLocale string
 			EditingValue  string
+			Editable      bool
+			Meta          *Meta
 		}
 
 		res.GetAdmin().RegisterFuncMap("i18n_available_translations", func(context *admin.Context) (results []matchedTranslation) {
@@ -324,19 +341,25 @@ func (i18n *I18n) ConfigureQorResource(res resource.Resourcer) {
 			var filterTranslations = func(translations map[string]*Translation, isPrimary bool) {
 				if translations != nil {
 					for key, translation := range translations {
-						if (keyword == "") || (strings.Index(strings.ToLower(translation.Key), keyword) != -1 ||
+						if (keyword == "") || (strings.Index(strings.ToLower(translation.Key), keyword) != -1 || strings.Index(strings.ToLower(translation.Context), keyword) != -1 ||
 							strings.Index(strings.ToLower(translation.Value), keyword) != -1) {
 							if _, ok := matchedTranslations[key]; !ok {
 								var t = matchedTranslation{
-									Key:           key,
+									Key:           translation.Key,
+									Context:       translation.Context,
 									PrimaryLocale: primaryLocale,
 									EditingLocale: editingLocale,
 									EditingValue:  translation.Value,
+									Editable:      i18n.Context(translation.Context).Editable(editingLocale, translation.Key),
+									Meta:          translation.Meta,
 								}
 
 								if localeTranslations, ok := translationsMap[primaryLocale]; ok {
 									if v, ok := localeTranslations[key]; ok {
 										t.PrimaryValue = v.Value
+										if t.Meta == nil {
+											t.Meta = v.Meta
+										}
 									}
 								}
 
@@ -9,6 +9,7 @@ type backend struct{}
 
 func (b *backend) LoadTranslations() (translations []*Translation) { return translations }
 func (b *backend) SaveTranslation(t *Translation) error            { return nil }
+func (b *backend) FindTranslation(t *Translation) Translation      { return Translation{} }
 func (b *backend) DeleteTranslation(t *Translation) error          { return nil }
 
 const BIGNUM = 10000
@@ -51,3 +52,91 @@ func TestFallbackLocale(t *testing.T) {
 		t.Errorf("Haven't setup any fallback")
 	}
 }
+
+func TestScope(t *testing.T) {
+	i18n := New(&backend{})
+	i18n.AddTranslation(&Translation{Key: "home.hello-world", Locale: "en-US", Value: "Hello Home"})
+	i18n.AddTranslation(&Translation{Key: "hello-world", Locale: "en-US", Value: "Hello World"})
+
+	if value := i18n.Scope("home").T("en-US", "hello-world"); value != "Hello Home" {
+		t.Errorf("should translate with scoped key, but got %v", value)
+	}
+}
+
+func TestLookup(t *testing.T) {
+	b := &backend{}
+	i18n := New(b)
+	i18n.AddTranslation(&Translation{Key: "hello-world", Locale: "en-US", Value: "Hello World"})
+	i18n.SaveTranslation(&Translation{Key: "hello-world", Locale: "zh-CN", Value: "你好世界"})
+
+	if result, ok := i18n.Lookup("zh-CN", "hello-world"); !ok || result.Value != "你好世界" || result.Fallback || result.Backend != b {
+		t.Errorf("should find translation from backend, but got %#v", result)
+	}
+
+	if result, ok := i18n.Lookup("fr-FR", "hello-world"); !ok || result.Locale != "en-US" || !result.Fallback || result.Backend != nil {
+		t.Errorf("should find translation from fallback locale, but got %#v", result)
+	}
+
+	if result, ok := i18n.Default("Missing").(*I18n).Lookup("fr-FR", "missing"); ok || result.Value != "Missing" || !result.Default {
+		t.Errorf("should return default value for missing translation, but got %#v", result)
+	}
+}
+
+func TestCompiledTranslations(t *testing.T) {
+	i18n := New(&backend{})
+	i18n.Formatter = ICUFormatter{}
+	i18n.AddTranslation(&Translation{Key: "count", Locale: "en-US", Value: "{count, plural, one {# item} other {# items}}"})
+
+	if value := i18n.T("en-US", "count", map[string]int{"count": 2}); value != "2 items" {
+		t.Errorf("should format translation, but got %v", value)
+	}
+
+	i18n.SaveTranslation(&Translation{Key: "count", Locale: "en-US", Value: "{count, plural, one {# product} other {# products}}"})
+	if value := i18n.T("en-US", "count", map[string]int{"count": 2}); value != "2 products" {
+		t.Errorf("should recompile translation after it changed, but got %v", value)
+	}
+
+	i18n.DeleteTranslation(&Translation{Key: "count", Locale: "en-US"})
+	if value := i18n.T("en-US", "count", map[string]int{"count": 2}); value != "count" {
+		t.Errorf("should remove compiled translation after it deleted, but got %v", value)
+	}
+}
+
+func benchmarkT(b *testing.B, formatter Formatter, value string, args ...interface{}) {
+	i18n := New(&backend{})
+	i18n.Formatter = formatter
+	i18n.AddTranslation(&Translation{Key: "benchmark", Locale: "en-US", Value: value})
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		i18n.T("en-US", "benchmark", args...)
+	}
+}
+
+func uncompiled(formatter Formatter) Formatter {
+	return FormatterFunc(formatter.Format)
+}
+
+func BenchmarkTPlainText(b *testing.B) {
+	benchmarkT(b, CLDRFormatter{}, "Hello World")
+}
+
+func BenchmarkTPlainTextUncompiled(b *testing.B) {
+	benchmarkT(b, uncompiled(CLDRFormatter{}), "Hello World")
+}
+
+func BenchmarkTPlural(b *testing.B) {
+	benchmarkT(b, CLDRFormatter{}, `{{p "Count" (one "{{.Count}} item") (other "{{.Count}} items")}}`, map[string]int{"Count": 2})
+}
+
+func BenchmarkTPluralUncompiled(b *testing.B) {
+	benchmarkT(b, uncompiled(CLDRFormatter{}), `{{p "Count" (one "{{.Count}} item") (other "{{.Count}} items")}}`, map[string]int{"Count": 2})
+}
+
+func BenchmarkTICU(b *testing.B) {
+	benchmarkT(b, ICUFormatter{}, "{count, plural, one {# item} other {# items}}", map[string]int{"count": 2})
+}
+
+func BenchmarkTICUUncompiled(b *testing.B) {
+	benchmarkT(b, uncompiled(ICUFormatter{}), "{count, plural, one {# item} other {# items}}", map[string]int{"count": 2})
+}
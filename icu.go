@@ -0,0 +1,449 @@
+package i18n
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+
+	"golang.org/x/text/feature/plural"
+	"golang.org/x/text/language"
+	"golang.org/x/text/message"
+	"golang.org/x/text/number"
+)
+
+// ICUFormatter format translation with ICU MessageFormat, e.g: `{count, plural, =0 {no items} one {# item} other {# items}}`
+//
+// Supported arguments are `{name}`, `{name, number}`, `{name, number, integer|percent}`, `{name, date|time, short|medium|long|full}`,
+// `{name, plural, ...}`, `{name, selectordinal, ...}` and `{name, select, ...}`, named arguments are read from the first argument (map or struct),
+// numbered arguments like `{0}` are read from positional arguments
+type ICUFormatter struct{}
+
+// Format format translation with ICU MessageFormat
+func (formatter ICUFormatter) Format(locale, value string, args ...interface{}) (string, error) {
+	tmpl, err := formatter.Compile(locale, value)
+	if err != nil {
+		return value, err
+	}
+	return tmpl.Execute(args...)
+}
+
+// Compile parse ICU message into reusable template
+func (ICUFormatter) Compile(locale, value string) (CompiledTemplate, error) {
+	nodes, err := parseICUMessage(value)
+	if err != nil {
+		return nil, err
+	}
+
+	tag := language.Make(locale)
+	return &icuTemplate{value: value, nodes: nodes, tag: tag, printer: message.NewPrinter(tag)}, nil
+}
+
+type icuTemplate struct {
+	value   string
+	nodes   []icuNode
+	tag     language.Tag
+	printer *message.Printer
+}
+
+// Execute format parsed ICU message with arguments
+func (tmpl *icuTemplate) Execute(args ...interface{}) (string, error) {
+	var builder strings.Builder
+	if err := formatICUNodes(&builder, tmpl.nodes, &icuContext{tag: tmpl.tag, printer: tmpl.printer, args: args}); err != nil {
+		return tmpl.value, err
+	}
+	return builder.String(), nil
+}
+
+type icuContext struct {
+	tag     language.Tag
+	printer *message.Printer
+	args    []interface{}
+	// number used to replace `#` in plural messages
+	pound interface{}
+}
+
+type icuNode struct {
+	text    string
+	pound   bool
+	arg     string
+	typ     string
+	style   string
+	offset  float64
+	options map[string][]icuNode
+}
+
+// ICU message parser
+
+type icuParser struct {
+	runes []rune
+	pos   int
+}
+
+func parseICUMessage(value string) ([]icuNode, error) {
+	parser := &icuParser{runes: []rune(value)}
+	nodes, err := parser.parseMessage(false)
+	if err == nil && parser.pos < len(parser.runes) {
+		err = parser.errorf("unexpected %q", parser.runes[parser.pos])
+	}
+	return nodes, err
+}
+
+func (parser *icuParser) errorf(format string, args ...interface{}) error {
+	return fmt.Errorf("icu: "+format+" at position %d", append(args, parser.pos)...)
+}
+
+func (parser *icuParser) peek(offset int) rune {
+	if parser.pos+offset < len(parser.runes) {
+		return parser.runes[parser.pos+offset]
+	}
+	return 0
+}
+
+// parseMessage parse message until `}` or end of value
+func (parser *icuParser) parseMessage(inPlural bool) (nodes []icuNode, err error) {
+	var text strings.Builder
+
+	flush := func() {
+		if text.Len() > 0 {
+			nodes = append(nodes, icuNode{text: text.String()})
+			text.Reset()
+		}
+	}
+
+	for parser.pos < len(parser.runes) {
+		switch r := parser.runes[parser.pos]; {
+		case r == '\'':
+			next := parser.peek(1)
+			if next == '\'' {
+				text.WriteRune('\'')
+				parser.pos += 2
+			} else if next == '{' || next == '}' || next == '|' || (next == '#' && inPlural) {
+				parser.pos++
+				for parser.pos < len(parser.runes) {
+					if parser.runes[parser.pos] == '\'' {
+						if parser.peek(1) == '\'' {
+							text.WriteRune('\'')
+							parser.pos += 2
+							continue
+						}
+						parser.pos++
+						break
+					}
+					text.WriteRune(parser.runes[parser.pos])
+					parser.pos++
+				}
+			} else {
+				text.WriteRune(r)
+				parser.pos++
+			}
+		case r == '{':
+			flush()
+			parser.pos++
+			node, err := parser.parseArgument(inPlural)
+			if err != nil {
+				return nodes, err
+			}
+			nodes = append(nodes, node)
+		case r == '}':
+			flush()
+			return nodes, nil
+		case r == '#' && inPlural:
+			flush()
+			nodes = append(nodes, icuNode{pound: true})
+			parser.pos++
+		default:
+			text.WriteRune(r)
+			parser.pos++
+		}
+	}
+
+	flush()
+	return nodes, nil
+}
+
+func (parser *icuParser) skipSpaces() {
+	for parser.pos < len(parser.runes) && unicode.IsSpace(parser.runes[parser.pos]) {
+		parser.pos++
+	}
+}
+
+// parseWord parse word until space or any of stop runes
+func (parser *icuParser) parseWord(stops string) string {
+	parser.skipSpaces()
+	start := parser.pos
+	for parser.pos < len(parser.runes) && !unicode.IsSpace(parser.runes[parser.pos]) && !strings.ContainsRune(stops, parser.runes[parser.pos]) {
+		parser.pos++
+	}
+	word := string(parser.runes[start:parser.pos])
+	parser.skipSpaces()
+	return word
+}
+
+// parseArgument parse argument after `{`, including its closing `}`
+func (parser *icuParser) parseArgument(inPlural bool) (node icuNode, err error) {
+	if node.arg = parser.parseWord(",}"); node.arg == "" {
+		return node, parser.errorf("missing argument name")
+	}
+
+	if parser.peek(0) == ',' {
+		parser.pos++
+		node.typ = parser.parseWord(",}")
+
+		switch node.typ {
+		case "plural", "selectordinal", "select":
+			if parser.peek(0) != ',' {
+				return node, parser.errorf("missing options of %v", node.typ)
+			}
+			parser.pos++
+			if err = parser.parseOptions(&node, inPlural || node.typ != "select"); err != nil {
+				return node, err
+			}
+		default:
+			if parser.peek(0) == ',' {
+				parser.pos++
+				start := parser.pos
+				for parser.pos < len(parser.runes) && parser.runes[parser.pos] != '}' {
+					parser.pos++
+				}
+				node.style = strings.TrimSpace(string(parser.runes[start:parser.pos]))
+			}
+		}
+	}
+
+	if parser.peek(0) != '}' {
+		return node, parser.errorf("unclosed argument %v", node.arg)
+	}
+	parser.pos++
+	return node, nil
+}
+
+// parseOptions parse options of plural, selectordinal and select, `#` in option messages will be replaced with number if inPlural is true
+func (parser *icuParser) parseOptions(node *icuNode, inPlural bool) error {
+	node.options = map[string][]icuNode{}
+
+	for {
+		selector := parser.parseWord("{}")
+		if selector == "" {
+			break
+		}
+
+		if strings.HasPrefix(selector, "offset:") && node.typ == "plural" {
+			offset, err := strconv.ParseFloat(strings.TrimPrefix(selector, "offset:"), 64)
+			if err != nil {
+				return parser.errorf("invalid offset %v", selector)
+			}
+			node.offset = offset
+			continue
+		}
+
+		if parser.peek(0) != '{' {
+			return parser.errorf("missing message for %v", selector)
+		}
+		parser.pos++
+
+		message, err := parser.parseMessage(inPlural)
+		if err != nil {
+			return err
+		}
+
+		if parser.peek(0) != '}' {
+			return parser.errorf("unclosed message for %v", selector)
+		}
+		parser.pos++
+		node.options[selector] = message
+	}
+
+	if _, ok := node.options["other"]; !ok {
+		return parser.errorf("missing `other` option for %v", node.arg)
+	}
+	return nil
+}
+
+// ICU message formatter
+
+func formatICUNodes(builder *strings.Builder, nodes []icuNode, context *icuContext) error {
+	for _, node := range nodes {
+		if err := formatICUNode(builder, node, context); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func formatICUNode(builder *strings.Builder, node icuNode, context *icuContext) error {
+	if node.pound {
+		builder.WriteString(context.printer.Sprint(number.Decimal(context.pound)))
+		return nil
+	}
+
+	if node.arg == "" {
+		builder.WriteString(node.text)
+		return nil
+	}
+
+	value, ok := icuArgument(node.arg, context.args)
+	if !ok {
+		return fmt.Errorf("icu: missing argument %v", node.arg)
+	}
+
+	switch node.typ {
+	case "":
+		builder.WriteString(fmt.Sprint(value))
+	case "number":
+		switch node.style {
+		case "integer":
+			builder.WriteString(context.printer.Sprint(number.Decimal(value, number.MaxFractionDigits(0))))
+		case "percent":
+			builder.WriteString(context.printer.Sprint(number.Percent(value)))
+		default:
+			builder.WriteString(context.printer.Sprint(number.Decimal(value)))
+		}
+	case "date", "time":
+		t, ok := value.(time.Time)
+		if !ok {
+			return fmt.Errorf("icu: argument %v is not a time", node.arg)
+		}
+		patterns := getDateTimeFormat(context.tag).date
+		if node.typ == "time" {
+			patterns = getDateTimeFormat(context.tag).time
+		}
+		builder.WriteString(formatDateTime(context.tag, patterns, node.style, t))
+	case "select":
+		message, ok := node.options[fmt.Sprint(value)]
+		if !ok {
+			message = node.options["other"]
+		}
+		return formatICUNodes(builder, message, context)
+	case "plural", "selectordinal":
+		n, operands, err := pluralOperands(value)
+		if err != nil {
+			return fmt.Errorf("icu: argument %v %v", node.arg, err)
+		}
+
+		message, ok := node.options["="+strconv.FormatFloat(n, 'f', -1, 64)]
+		if node.offset != 0 {
+			n -= node.offset
+			_, operands, _ = pluralOperands(n)
+		}
+
+		if !ok {
+			rules := plural.Cardinal
+			if node.typ == "selectordinal" {
+				rules = plural.Ordinal
+			}
+
+			if message, ok = node.options[pluralCategory(rules, context.tag, operands)]; !ok {
+				message = node.options["other"]
+			}
+		}
+
+		pluralContext := *context
+		pluralContext.pound = n
+		return formatICUNodes(builder, message, &pluralContext)
+	default:
+		return fmt.Errorf("icu: unsupported argument type %v", node.typ)
+	}
+	return nil
+}
+
+// icuArgument get argument by name, numbered name like `0` is used as index of positional arguments, others are read from the first argument
+func icuArgument(name string, args []interface{}) (interface{}, bool) {
+	if idx, err := strconv.Atoi(name); err == nil {
+		if idx >= 0 && idx < len(args) {
+			return args[idx], true
+		}
+		return nil, false
+	}
+
+	if len(args) == 0 || args[0] == nil {
+		return nil, false
+	}
+
+	value := reflect.Indirect(reflect.ValueOf(args[0]))
+	switch value.Kind() {
+	case reflect.Map:
+		if value.Type().Key().Kind() == reflect.String {
+			if v := value.MapIndex(reflect.ValueOf(name).Convert(value.Type().Key())); v.IsValid() {
+				return v.Interface(), true
+			}
+		}
+	case reflect.Struct:
+		for _, fieldName := range []string{name, strings.ToUpper(name[:1]) + name[1:]} {
+			if field := value.FieldByName(fieldName); field.IsValid() && field.CanInterface() {
+				return field.Interface(), true
+			}
+		}
+	}
+	return nil, false
+}
+
+// pluralOperands return number and its CLDR plural operands `i`, `v`, `w`, `f`, `t` for value
+func pluralOperands(value interface{}) (float64, [5]int, error) {
+	var (
+		operands [5]int
+		str      string
+	)
+
+	switch v := value.(type) {
+	case string:
+		str = strings.TrimSpace(v)
+	case float32:
+		str = strconv.FormatFloat(float64(v), 'f', -1, 32)
+	case float64:
+		str = strconv.FormatFloat(v, 'f', -1, 64)
+	default:
+		rv := reflect.ValueOf(value)
+		switch rv.Kind() {
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+			str = strconv.FormatInt(rv.Int(), 10)
+		case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+			str = strconv.FormatUint(rv.Uint(), 10)
+		default:
+			return 0, operands, errors.New("is not a number")
+		}
+	}
+
+	n, err := strconv.ParseFloat(str, 64)
+	if err != nil {
+		return 0, operands, errors.New("is not a number")
+	}
+
+	var (
+		parts    = strings.SplitN(strings.TrimLeft(str, "+-"), ".", 2)
+		integer  = parts[0]
+		fraction string
+	)
+	if len(parts) > 1 {
+		fraction = parts[1]
+	}
+
+	operands[0], _ = strconv.Atoi(integer)
+	operands[1] = len(fraction)
+	operands[3], _ = strconv.Atoi(fraction)
+	trimmed := strings.TrimRight(fraction, "0")
+	operands[2] = len(trimmed)
+	operands[4], _ = strconv.Atoi(trimmed)
+	return n, operands, nil
+}
+
+// pluralCategory return CLDR plural category for operands
+func pluralCategory(rules *plural.Rules, tag language.Tag, operands [5]int) string {
+	switch rules.MatchPlural(tag, operands[0], operands[1], operands[2], operands[3], operands[4]) {
+	case plural.Zero:
+		return "zero"
+	case plural.One:
+		return "one"
+	case plural.Two:
+		return "two"
+	case plural.Few:
+		return "few"
+	case plural.Many:
+		return "many"
+	default:
+		return "other"
+	}
+}
@@ -0,0 +1,61 @@
+package i18n
+
+import (
+	"testing"
+	"time"
+)
+
+type icuTestCase struct {
+	Locale string
+	Value  string
+	Args   []interface{}
+	Expect string
+}
+
+func TestICUFormatter(t *testing.T) {
+	date := time.Date(2017, 3, 5, 14, 8, 0, 0, time.UTC)
+	testCases := []icuTestCase{
+		{Locale: "en-US", Value: "Hello {name}", Args: []interface{}{map[string]string{"name": "Jinzhu"}}, Expect: "Hello Jinzhu"},
+		{Locale: "en-US", Value: "Hello {Name}", Args: []interface{}{struct{ Name string }{Name: "Jinzhu"}}, Expect: "Hello Jinzhu"},
+		{Locale: "en-US", Value: "{0} and {1}", Args: []interface{}{"A", "B"}, Expect: "A and B"},
+		{Locale: "en-US", Value: "{count, plural, =0 {no items} one {# item} other {# items}}", Args: []interface{}{map[string]int{"count": 0}}, Expect: "no items"},
+		{Locale: "en-US", Value: "{count, plural, =0 {no items} one {# item} other {# items}}", Args: []interface{}{map[string]int{"count": 1}}, Expect: "1 item"},
+		{Locale: "en-US", Value: "{count, plural, =0 {no items} one {# item} other {# items}}", Args: []interface{}{map[string]int{"count": 1200}}, Expect: "1,200 items"},
+		{Locale: "pl", Value: "{count, plural, one {# plik} few {# pliki} many {# plików} other {# pliku}}", Args: []interface{}{map[string]int{"count": 22}}, Expect: "22 pliki"},
+		{Locale: "en-US", Value: "{count, plural, offset:1 =0 {nobody} =1 {{name}} one {{name} and # other} other {{name} and # others}}", Args: []interface{}{map[string]interface{}{"count": 3, "name": "Jinzhu"}}, Expect: "Jinzhu and 2 others"},
+		{Locale: "en-US", Value: "{rank, selectordinal, one {#st} two {#nd} few {#rd} other {#th}}", Args: []interface{}{map[string]int{"rank": 22}}, Expect: "22nd"},
+		{Locale: "fr-FR", Value: "{gender, select, male {Il} female {Elle} other {Iel}} a {count, plural, one {# message} other {# messages}}", Args: []interface{}{map[string]interface{}{"gender": "female", "count": 2}}, Expect: "Elle a 2 messages"},
+		{Locale: "de-DE", Value: "{amount, number}", Args: []interface{}{map[string]float64{"amount": 1234.5}}, Expect: "1.234,5"},
+		{Locale: "en-US", Value: "It''s '{quoted}'", Expect: "It's {quoted}"},
+		{Locale: "de-DE", Value: "{created, date, full}", Args: []interface{}{map[string]time.Time{"created": date}}, Expect: "Sonntag, 5. März 2017"},
+		{Locale: "en-US", Value: "{created, date, short} {created, time, short}", Args: []interface{}{map[string]time.Time{"created": date}}, Expect: "3/5/17 2:08 PM"},
+		{Locale: "fr-FR", Value: "{created, time, short}", Args: []interface{}{map[string]time.Time{"created": date}}, Expect: "14:08"},
+	}
+
+	for i, testCase := range testCases {
+		if value, err := (ICUFormatter{}).Format(testCase.Locale, testCase.Value, testCase.Args...); err != nil || value != testCase.Expect {
+			t.Errorf("#%d: expect %v, but got %v (%v)", i+1, testCase.Expect, value, err)
+		}
+	}
+
+	for _, value := range []string{"{count, plural, one {# item}}", "{name", "{count, plural, one {# item} other {# items}"} {
+		if _, err := (ICUFormatter{}).Format("en-US", value); err == nil {
+			t.Errorf("should return error for invalid message %v", value)
+		}
+	}
+}
+
+func TestI18nFormatter(t *testing.T) {
+	i18n := New(&backend{})
+	i18n.Formatter = DetectFormatter{}
+	i18n.AddTranslation(&Translation{Key: "icu", Locale: "en-US", Value: "{count, plural, one {# item} other {# items}}"})
+	i18n.AddTranslation(&Translation{Key: "template", Locale: "en-US", Value: "Hello {{$1}}"})
+
+	if value := i18n.T("en-US", "icu", map[string]int{"count": 2}); value != "2 items" {
+		t.Errorf("should format ICU message, but got %v", value)
+	}
+
+	if value := i18n.T("en-US", "template", "Jinzhu"); value != "Hello Jinzhu" {
+		t.Errorf("should format golang template, but got %v", value)
+	}
+}
@@ -0,0 +1,219 @@
+package i18n
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"sort"
+	"sync"
+	"sync/atomic"
+)
+
+// maxUnknownLocales max locales lazily loaded if backends couldn't list their locales, so arbitrary locales won't be loaded and kept forever
+const maxUnknownLocales = 100
+
+// LocaleLoader backends that could load translations of a locale
+type LocaleLoader interface {
+	LoadLocale(locale string) []*Translation
+}
+
+// LocaleLoaderV2 BackendV2 that could load translations of a locale
+type LocaleLoaderV2 interface {
+	LoadLocale(ctx context.Context, locale string) ([]*Translation, error)
+}
+
+// LocaleLister backends that could list their locales, lazy I18n only loads locales that backends have
+type LocaleLister interface {
+	Locales() []string
+}
+
+// LocaleListerV2 BackendV2 that could list their locales
+type LocaleListerV2 interface {
+	Locales(ctx context.Context) ([]string, error)
+}
+
+// availableLocales locales of backends for lazy I18n, locales is nil if any backend couldn't list its locales
+type availableLocales struct {
+	value   atomic.Value
+	unknown int32
+}
+
+// NewLazy initialize I18n that loads translations of a locale when it is used at the first time, locales in eagerLocales will be loaded immediately
+// backends that implement LocaleLoader could load translations of a locale efficiently, otherwise translations of all locales will be loaded and filtered
+// backends that implement LocaleLister only load locales they have, otherwise at most 100 unknown locales will be loaded lazily
+// errors of loading eager locales are logged like New, use LoadLocales to handle them
+func NewLazy(eagerLocales []string, backends ...Backend) *I18n {
+	i18n := newI18n(backends...)
+	i18n.lazy = true
+	i18n.locales = &sync.Map{}
+	i18n.availableLocales = &availableLocales{}
+	if err := i18n.listLocales(context.Background()); err != nil {
+		log.Printf("i18n: failed to list locales, got %v", err)
+	}
+	if err := i18n.LoadLocales(context.Background(), eagerLocales...); err != nil {
+		log.Printf("i18n: failed to load locales, got %v", err)
+	}
+	return i18n
+}
+
+// LoadLocales load translations of locales, translations of loaded locales will be reloaded
+func (i18n *I18n) LoadLocales(ctx context.Context, locales ...string) error {
+	if len(locales) == 0 {
+		return nil
+	}
+
+	i18n.snapshot.reload.Lock()
+	defer i18n.snapshot.reload.Unlock()
+
+	if i18n.lazy {
+		for _, locale := range locales {
+			i18n.locales.Store(locale, true)
+		}
+	}
+	return i18n.load(ctx, locales)
+}
+
+// ensureLocale load translations of locale if it hasn't been loaded for lazy I18n
+func (i18n *I18n) ensureLocale(locale string) {
+	if !i18n.lazy {
+		return
+	}
+
+	if _, ok := i18n.locales.Load(locale); ok {
+		return
+	}
+
+	// locales that backends don't have won't be loaded, so they won't take the reload lock
+	locales, _ := i18n.availableLocales.value.Load().(map[string]bool)
+	if locales != nil {
+		if !locales[locale] && !i18n.snapshot.hasLocale(locale) {
+			return
+		}
+	} else if atomic.LoadInt32(&i18n.availableLocales.unknown) >= maxUnknownLocales {
+		return
+	}
+
+	i18n.snapshot.reload.Lock()
+	defer i18n.snapshot.reload.Unlock()
+
+	// mark locale as loaded even failed to load it, it will be loaded again when reloading
+	if _, loaded := i18n.locales.LoadOrStore(locale, true); !loaded {
+		if locales == nil {
+			atomic.AddInt32(&i18n.availableLocales.unknown, 1)
+		}
+		i18n.load(context.Background(), []string{locale})
+	}
+}
+
+// listLocales list locales of backends for lazy I18n, caller should hold the reload lock
+func (i18n *I18n) listLocales(ctx context.Context) error {
+	var locales = map[string]bool{}
+	for _, backend := range i18n.Backends {
+		results, ok, err := listBackendLocales(ctx, backend)
+		if err != nil {
+			return err
+		}
+
+		if !ok {
+			locales = nil
+			break
+		}
+
+		for _, locale := range results {
+			locales[locale] = true
+		}
+	}
+
+	i18n.availableLocales.value.Store(locales)
+	return nil
+}
+
+// listBackendLocales list locales of backend, ok is false if backend couldn't list its locales
+func listBackendLocales(ctx context.Context, backend Backend) (locales []string, ok bool, err error) {
+	if adapter, ok := backend.(*backendV2Adapter); ok {
+		if lister, ok := adapter.backend.(LocaleListerV2); ok {
+			locales, err = lister.Locales(ctx)
+			return locales, true, err
+		}
+		return nil, false, nil
+	}
+
+	if lister, ok := backend.(LocaleLister); ok {
+		if err := ctx.Err(); err != nil {
+			return nil, true, err
+		}
+
+		defer func() {
+			if r := recover(); r != nil {
+				err = fmt.Errorf("failed to list locales: %v", r)
+			}
+		}()
+		return lister.Locales(), true, nil
+	}
+	return nil, false, nil
+}
+
+// loadedLocales return loaded locales of lazy I18n
+func (i18n *I18n) loadedLocales() (locales []string) {
+	i18n.locales.Range(func(locale, _ interface{}) bool {
+		locales = append(locales, locale.(string))
+		return true
+	})
+	sort.Strings(locales)
+	return locales
+}
+
+// loadLocaleTranslations load translations of locales from backend, load all translations if locales is nil
+func loadLocaleTranslations(ctx context.Context, backend Backend, locales []string) (translations []*Translation, err error) {
+	if locales == nil {
+		return loadTranslations(ctx, backend)
+	}
+
+	if loader, ok := backend.(contextLoader); ok {
+		return loader.loadContext(ctx, locales)
+	}
+
+	var loadLocale func(locale string) ([]*Translation, error)
+	if adapter, ok := backend.(*backendV2Adapter); ok {
+		if loader, ok := adapter.backend.(LocaleLoaderV2); ok {
+			loadLocale = func(locale string) ([]*Translation, error) {
+				return loader.LoadLocale(ctx, locale)
+			}
+		}
+	} else if loader, ok := backend.(LocaleLoader); ok {
+		loadLocale = func(locale string) (results []*Translation, err error) {
+			if err := ctx.Err(); err != nil {
+				return nil, err
+			}
+
+			defer func() {
+				if r := recover(); r != nil {
+					err = fmt.Errorf("failed to load translations of %v: %v", locale, r)
+				}
+			}()
+			return loader.LoadLocale(locale), nil
+		}
+	}
+
+	if loadLocale != nil {
+		for _, locale := range locales {
+			results, err := loadLocale(locale)
+			if err != nil {
+				return nil, err
+			}
+			translations = append(translations, results...)
+		}
+		return translations, nil
+	}
+
+	results, err := loadTranslations(ctx, backend)
+	for _, translation := range results {
+		for _, locale := range locales {
+			if translation.Locale == locale {
+				translations = append(translations, translation)
+				break
+			}
+		}
+	}
+	return translations, err
+}
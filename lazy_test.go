@@ -0,0 +1,130 @@
+package i18n
+
+import (
+	"context"
+	"fmt"
+	"testing"
+)
+
+type localeBackend struct {
+	memoryBackend
+	loaded []string
+}
+
+func (b *localeBackend) LoadLocale(locale string) (translations []*Translation) {
+	b.loaded = append(b.loaded, locale)
+	for _, translation := range b.translations {
+		if translation.Locale == locale {
+			translations = append(translations, translation)
+		}
+	}
+	return translations
+}
+
+func TestLazyLoad(t *testing.T) {
+	b := &localeBackend{memoryBackend: memoryBackend{translations: map[string]*Translation{}}}
+	for _, locale := range []string{"en-US", "zh-CN", "ja-JP", "de-DE"} {
+		b.SaveTranslation(&Translation{Key: "hello", Locale: locale, Value: "hello " + locale})
+	}
+	files := &readOnlyBackend{translations: []*Translation{{Key: "bye", Locale: "zh-CN", Value: "再见"}, {Key: "bye", Locale: "ja-JP", Value: "さようなら"}}}
+
+	i18n := NewLazy([]string{"en-US"}, b, files)
+	if len(b.loaded) != 1 || b.loaded[0] != "en-US" {
+		t.Errorf("should only load eager locales, but loaded %v", b.loaded)
+	}
+
+	if _, ok := i18n.snapshot.get("zh-CN", "hello"); ok {
+		t.Errorf("locales shouldn't be loaded before used")
+	}
+
+	if value := i18n.T("zh-CN", "hello"); value != "hello zh-CN" {
+		t.Errorf("should load locale when it is used, but got %v", value)
+	}
+
+	if value := i18n.T("zh-CN", "bye"); value != "再见" {
+		t.Errorf("should load locale from backends without LoadLocale, but got %v", value)
+	}
+
+	if _, ok := i18n.snapshot.get("ja-JP", "bye"); ok {
+		t.Errorf("translations of other locales shouldn't be loaded from backends without LoadLocale")
+	}
+
+	if len(b.loaded) != 2 {
+		t.Errorf("locale should only be loaded once, but loaded %v", b.loaded)
+	}
+
+	b.SaveTranslation(&Translation{Key: "hello", Locale: "zh-CN", Value: "你好"})
+	i18n.Reload()
+	if value := i18n.T("zh-CN", "hello"); value != "你好" {
+		t.Errorf("should reload loaded locales, but got %v", value)
+	}
+
+	if _, ok := i18n.snapshot.get("de-DE", "hello"); ok {
+		t.Errorf("reload shouldn't load locales that haven't been used")
+	}
+
+	if err := i18n.LoadLocales(context.Background(), "de-DE"); err != nil {
+		t.Errorf("failed to load locales, got %v", err)
+	}
+
+	if value, ok := i18n.snapshot.get("de-DE", "hello"); !ok || value.Value != "hello de-DE" {
+		t.Errorf("should load locales, but got %v", value)
+	}
+}
+
+func TestLazyLoadWithoutEagerLocales(t *testing.T) {
+	b := &localeBackend{memoryBackend: memoryBackend{translations: map[string]*Translation{}}}
+	b.SaveTranslation(&Translation{Key: "hello", Locale: "en-US", Value: "Hello"})
+
+	i18n := NewLazy(nil, b)
+	if err := i18n.Reload(); err != nil {
+		t.Errorf("failed to reload, got %v", err)
+	}
+
+	if _, ok := i18n.snapshot.get("en-US", "hello"); ok {
+		t.Errorf("no locales should be loaded without eager locales")
+	}
+
+	if NewLazy([]string{"en-US"}, panicBackend{}) == nil {
+		t.Errorf("NewLazy should log errors of loading eager locales")
+	}
+}
+
+type listerBackend struct {
+	localeBackend
+}
+
+func (b *listerBackend) Locales() (locales []string) {
+	for _, translation := range b.translations {
+		locales = append(locales, translation.Locale)
+	}
+	return locales
+}
+
+func TestLazyLoadAvailableLocales(t *testing.T) {
+	b := &listerBackend{localeBackend{memoryBackend: memoryBackend{translations: map[string]*Translation{}}}}
+	b.SaveTranslation(&Translation{Key: "hello", Locale: "en-US", Value: "Hello"})
+
+	i18n := NewLazy(nil, b)
+	for _, locale := range []string{"xx", "yy", "en-US", "xx"} {
+		i18n.Lookup(locale, "hello")
+	}
+	if len(b.loaded) != 1 || b.loaded[0] != "en-US" {
+		t.Errorf("should only load locales that backends have, but loaded %v", b.loaded)
+	}
+
+	b.SaveTranslation(&Translation{Key: "hello", Locale: "xx", Value: "Hello xx"})
+	i18n.Reload()
+	if result, ok := i18n.Lookup("xx", "hello"); !ok || result.Value != "Hello xx" {
+		t.Errorf("should load new locales of backends after reloading, but got %v", result.Value)
+	}
+
+	unknown := &localeBackend{memoryBackend: memoryBackend{translations: map[string]*Translation{}}}
+	i18n = NewLazy(nil, unknown)
+	for i := 0; i < maxUnknownLocales*2; i++ {
+		i18n.Lookup(fmt.Sprintf("locale-%v", i), "hello")
+	}
+	if len(unknown.loaded) != maxUnknownLocales {
+		t.Errorf("should load at most %v locales if backends couldn't list locales, but loaded %v", maxUnknownLocales, len(unknown.loaded))
+	}
+}
@@ -0,0 +1,101 @@
+package i18n
+
+import (
+	"reflect"
+	"strings"
+)
+
+// Result is the result of translation lookup
+type Result struct {
+	// Key translation key with scope
+	Key string
+	// Context message context of the translation
+	Context string
+	// Value resolved value, it is the default value if translation is missing
+	Value string
+	// RequestedLocale locale used to lookup translation
+	RequestedLocale string
+	// Locale locale that the translation was actually found in
+	Locale string
+	// Fallback translation is found in one of the fallback locales
+	Fallback bool
+	// Default translation is missing, default value is used
+	Default bool
+	// Backend backend that supplied the translation, it is nil if the translation is added with AddTranslation
+	Backend Backend
+	// Meta metadata of the translation
+	Meta *Meta
+}
+
+// cachedTranslation translation saved in cache store, with position of its backend
+type cachedTranslation struct {
+	Translation
+	// BackendIndex index of backend plus one, zero means unknown backend
+	BackendIndex int `json:",omitempty"`
+}
+
+// Lookup lookup translation with locale, fallback locales, and scope, it has no side effects like T for missing translations
+func (i18n *I18n) Lookup(locale, key string) (Result, bool) {
+	if locale == "" {
+		locale = Default
+	}
+
+	var (
+		result     = Result{Key: i18n.scopedKey(key), Context: i18n.msgctxt, RequestedLocale: locale, Locale: locale}
+		messageKey = MessageKey(result.Context, result.Key)
+	)
+
+	for _, l := range append([]string{locale}, i18n.getFallbackLocales(locale)...) {
+		i18n.ensureLocale(l)
+		if translation, ok := i18n.snapshot.get(l, messageKey); ok && translation.Value != "" {
+			result.Value = translation.Value
+			result.Meta = translation.Meta
+			result.Locale = l
+			result.Fallback = l != locale
+			if translation.BackendIndex > 0 && translation.BackendIndex <= len(i18n.Backends) {
+				result.Backend = i18n.Backends[translation.BackendIndex-1]
+			}
+			return result, true
+		}
+	}
+
+	result.Value = i18n.value
+	result.Default = true
+	return result, false
+}
+
+func (i18n *I18n) scopedKey(key string) string {
+	if i18n.scope != "" {
+		return strings.Join([]string{i18n.scope, key}, ".")
+	}
+	return key
+}
+
+// getFallbackLocales return fallback locales of locale, ordered by priority: configured fallback locales of locale, fallback locales of i18n, then parent locales if ParentFallbacks is enabled
+func (i18n *I18n) getFallbackLocales(locale string) (fallbackLocales []string) {
+	if locales, ok := i18n.FallbackLocales[locale]; ok {
+		fallbackLocales = append(fallbackLocales, locales...)
+	}
+
+	fallbackLocales = append(fallbackLocales, i18n.fallbackLocales...)
+
+	if i18n.ParentFallbacks {
+		fallbackLocales = append(fallbackLocales, ParentLocales(locale)...)
+	}
+
+	return append(fallbackLocales, Default)
+}
+
+func (i18n *I18n) backendIndex(backend Backend) int {
+	for idx, b := range i18n.Backends {
+		if sameBackend(b, backend) {
+			return idx
+		}
+	}
+	return -1
+}
+
+// sameBackend return true if backends are same, backends that are not comparable are not same
+func sameBackend(a, b Backend) bool {
+	return a != nil && b != nil && reflect.TypeOf(a).Comparable() && reflect.TypeOf(b).Comparable() && a == b
+}
@@ -0,0 +1,35 @@
+package i18n
+
+import "time"
+
+// Meta metadata of translation, it helps translators understand what a key means and where it appears
+type Meta struct {
+	// Description developer description or comment of the key
+	Description string `json:",omitempty" yaml:"description,omitempty"`
+	// MaxLength max length (in characters) of translation value, zero means no limit
+	MaxLength int `json:",omitempty" yaml:"max_length,omitempty"`
+	// Placeholders placeholders used in translation value, e.g: `{{.Name}}`, `{count}`
+	Placeholders []string `json:",omitempty" yaml:"placeholders,omitempty"`
+	// Tags tags of the key, e.g: `checkout`, `email`
+	Tags []string `json:",omitempty" yaml:"tags,omitempty"`
+	// Source source file and line that uses the key, e.g: `app/views/home.tmpl:12`
+	Source string `json:",omitempty" yaml:"source,omitempty"`
+	// UpdatedAt, UpdatedBy when and who updated the translation
+	UpdatedAt time.Time `json:",omitempty" yaml:"updated_at,omitempty"`
+	UpdatedBy string    `json:",omitempty" yaml:"updated_by,omitempty"`
+	// Origin where the change of translation came from, e.g: OriginAdmin, OriginImport, OriginAPI
+	Origin string `json:",omitempty" yaml:"-"`
+}
+
+// Origins of translation changes
+const (
+	OriginAdmin   = "admin"
+	OriginImport  = "import"
+	OriginAPI     = "api"
+	OriginRestore = "restore"
+)
+
+// Exceeded return true if value is longer than max length
+func (meta *Meta) Exceeded(value string) bool {
+	return meta != nil && meta.MaxLength > 0 && len([]rune(value)) > meta.MaxLength
+}
@@ -0,0 +1,21 @@
+package i18n
+
+import "testing"
+
+func TestMeta(t *testing.T) {
+	i18n := New(&backend{})
+	i18n.AddTranslation(&Translation{Key: "hello", Locale: "en-US", Value: "Hello", Meta: &Meta{Description: "Greeting on home page", MaxLength: 5}})
+
+	result, ok := i18n.Lookup("zh-CN", "hello")
+	if !ok || result.Meta == nil || result.Meta.Description != "Greeting on home page" {
+		t.Fatalf("should return metadata of translation, but got %#v", result.Meta)
+	}
+
+	if result.Meta.Exceeded("Hello") || !result.Meta.Exceeded("Hello World") {
+		t.Errorf("should check max length of value")
+	}
+
+	if (&Meta{MaxLength: 2}).Exceeded("你好") || (*Meta)(nil).Exceeded("Hello") {
+		t.Errorf("should count characters, and nil metadata has no limit")
+	}
+}
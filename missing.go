@@ -0,0 +1,201 @@
+package i18n
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+)
+
+// MissingPolicy defines how to handle missing translations in T
+type MissingPolicy int
+
+const (
+	// MissingAutoCreate create missing translations in the first writable backend synchronously
+	MissingAutoCreate MissingPolicy = iota
+	// MissingRecord record missing translations in memory, they could be saved with FlushMissingTranslations in batch
+	MissingRecord
+	// MissingOff do nothing for missing translations
+	MissingOff
+)
+
+// maxMissingTranslations max missing translations recorded before flush, missing translations exceed it are dropped
+const maxMissingTranslations = 10000
+
+// missingTranslations deduped missing translations waiting for flush, keys are removed from seen once they are flushed
+type missingTranslations struct {
+	mutex   sync.Mutex
+	seen    map[string]bool
+	pending []*Translation
+	timer   *time.Timer
+}
+
+// handleMissingTranslation handle missing translation with missing policy, return its value
+func (i18n *I18n) handleMissingTranslation(translation *Translation) string {
+	switch i18n.MissingPolicy {
+	case MissingAutoCreate:
+		if backend, _ := i18n.writableBackend(translation); backend != nil {
+			translation.Backend = backend
+			if t, ok, err := findTranslation(context.Background(), backend, translation); ok {
+				return t.Value
+			} else if err == nil {
+				i18n.SaveTranslation(translation)
+			}
+		}
+	case MissingRecord:
+		i18n.recordMissingTranslation(translation)
+	}
+	return translation.Value
+}
+
+func (i18n *I18n) recordMissingTranslation(translation *Translation) {
+	missing := i18n.missingTranslations
+	if missing == nil {
+		return
+	}
+
+	missing.mutex.Lock()
+	defer missing.mutex.Unlock()
+
+	key := cacheKey(translation.Locale, translation.messageKey())
+	if missing.seen[key] || len(missing.seen) >= maxMissingTranslations {
+		return
+	}
+
+	if missing.seen == nil {
+		missing.seen = map[string]bool{}
+	}
+	missing.seen[key] = true
+	missing.pending = append(missing.pending, translation)
+
+	if i18n.MissingFlushInterval > 0 && missing.timer == nil {
+		missing.timer = time.AfterFunc(i18n.MissingFlushInterval, func() {
+			i18n.FlushMissingTranslations()
+		})
+	}
+}
+
+// MissingTranslations return recorded missing translations that haven't been flushed
+func (i18n *I18n) MissingTranslations() []*Translation {
+	missing := i18n.missingTranslations
+	if missing == nil {
+		return nil
+	}
+
+	missing.mutex.Lock()
+	defer missing.mutex.Unlock()
+	return append([]*Translation{}, missing.pending...)
+}
+
+// FlushMissingTranslations save recorded missing translations into the first writable backend in batch, existing translations won't be overwritten
+// translations failed to save are kept, they will be saved in next flush
+func (i18n *I18n) FlushMissingTranslations() error {
+	missing := i18n.missingTranslations
+	if missing == nil {
+		return nil
+	}
+
+	missing.mutex.Lock()
+	pending := missing.pending
+	missing.pending = nil
+	if missing.timer != nil {
+		missing.timer.Stop()
+		missing.timer = nil
+	}
+	missing.mutex.Unlock()
+
+	if len(pending) == 0 {
+		return nil
+	}
+
+	backend, _ := i18n.writableBackend(nil)
+	if backend == nil {
+		i18n.requeueMissingTranslations(pending)
+		return errors.New("no writable backend to save missing translations")
+	}
+
+	existing, err := findExistingTranslations(context.Background(), backend, pending)
+	if err != nil {
+		i18n.requeueMissingTranslations(pending)
+		return err
+	}
+
+	var found, unsaved []*Translation
+	for _, translation := range pending {
+		if t, ok := existing[cacheKey(translation.Locale, translation.messageKey())]; ok {
+			if t.Value != "" {
+				t.Backend = backend
+				i18n.AddTranslation(t)
+			}
+			found = append(found, translation)
+			continue
+		}
+		unsaved = append(unsaved, translation)
+	}
+	i18n.forgetMissingTranslations(found)
+
+	if len(unsaved) == 0 {
+		return nil
+	}
+
+	if err := i18n.SaveTranslations(unsaved); err != nil {
+		i18n.requeueMissingTranslations(unsaved)
+		return err
+	}
+	i18n.forgetMissingTranslations(unsaved)
+	return nil
+}
+
+// findExistingTranslations find missing translations that exist in backend, only their keys are checked if backend is a Finder, otherwise translations of backend are loaded once
+func findExistingTranslations(ctx context.Context, backend Backend, translations []*Translation) (map[string]*Translation, error) {
+	existing := map[string]*Translation{}
+
+	if !isFinder(backend) {
+		loaded, err := loadTranslations(ctx, backend)
+		if err != nil {
+			return nil, err
+		}
+		for _, t := range loaded {
+			existing[cacheKey(t.Locale, t.messageKey())] = t.copy()
+		}
+		return existing, nil
+	}
+
+	for _, translation := range translations {
+		t, _, err := findTranslation(ctx, backend, translation)
+		if err != nil {
+			return nil, err
+		}
+		if t.Key != "" {
+			existing[cacheKey(translation.Locale, translation.messageKey())] = t.copy()
+		}
+	}
+	return existing, nil
+}
+
+// forgetMissingTranslations remove flushed missing translations from seen, so they could be recorded again if they are deleted later
+func (i18n *I18n) forgetMissingTranslations(translations []*Translation) {
+	missing := i18n.missingTranslations
+
+	missing.mutex.Lock()
+	defer missing.mutex.Unlock()
+
+	for _, translation := range translations {
+		delete(missing.seen, cacheKey(translation.Locale, translation.messageKey()))
+	}
+}
+
+// requeueMissingTranslations put missing translations that failed to save back to pending, so they will be saved in next flush
+func (i18n *I18n) requeueMissingTranslations(translations []*Translation) {
+	missing := i18n.missingTranslations
+
+	missing.mutex.Lock()
+	defer missing.mutex.Unlock()
+
+	missing.pending = append(translations, missing.pending...)
+	if i18n.MissingFlushInterval > 0 && missing.timer == nil {
+		missing.timer = time.AfterFunc(i18n.MissingFlushInterval, func() {
+			i18n.FlushMissingTranslations()
+		})
+	}
+}
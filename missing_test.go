@@ -0,0 +1,164 @@
+package i18n
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+)
+
+type recordBackend struct {
+	backend
+	saved []*Translation
+}
+
+func (b *recordBackend) SaveTranslation(t *Translation) error {
+	b.saved = append(b.saved, t)
+	return nil
+}
+
+func TestMissingPolicy(t *testing.T) {
+	b := &recordBackend{}
+	i18n := New(b)
+
+	i18n.MissingPolicy = MissingOff
+	if value := i18n.T("en-US", "missing"); value != "missing" || len(b.saved) != 0 {
+		t.Errorf("shouldn't save missing translation when policy is off")
+	}
+
+	i18n.MissingPolicy = MissingRecord
+	for i := 0; i < 3; i++ {
+		i18n.T("en-US", "missing")
+		i18n.Scope("home").T("en-US", "missing")
+	}
+
+	if len(b.saved) != 0 {
+		t.Errorf("shouldn't save missing translations before flush")
+	}
+
+	if missing := i18n.MissingTranslations(); len(missing) != 2 {
+		t.Errorf("should record deduped missing translations, but got %v", len(missing))
+	}
+
+	if err := i18n.FlushMissingTranslations(); err != nil || len(b.saved) != 2 {
+		t.Errorf("should save missing translations after flush, got %v, %v", err, len(b.saved))
+	}
+
+	if missing := i18n.MissingTranslations(); len(missing) != 0 {
+		t.Errorf("should clear missing translations after flush")
+	}
+
+	i18n.MissingPolicy = MissingAutoCreate
+	i18n.T("en-US", "auto-created")
+	if len(b.saved) != 3 {
+		t.Errorf("should save missing translation with auto create policy")
+	}
+}
+
+func TestMissingFlushInterval(t *testing.T) {
+	i18n := New(&backend{})
+	i18n.MissingPolicy = MissingRecord
+	i18n.MissingFlushInterval = 10 * time.Millisecond
+
+	i18n.T("en-US", "missing")
+	time.Sleep(50 * time.Millisecond)
+
+	if missing := i18n.MissingTranslations(); len(missing) != 0 {
+		t.Errorf("should flush missing translations asynchronously")
+	}
+}
+
+func TestMissingWithoutBackends(t *testing.T) {
+	i18n := New()
+	if value := i18n.T("en-US", "missing"); value != "missing" {
+		t.Errorf("should return key without backends, but got %v", value)
+	}
+}
+
+type failingBackend struct {
+	recordBackend
+	failures int
+}
+
+func (b *failingBackend) SaveTranslation(t *Translation) error {
+	if b.failures > 0 {
+		b.failures--
+		return errors.New("failed to save translation")
+	}
+	return b.recordBackend.SaveTranslation(t)
+}
+
+func TestMissingFlushFailed(t *testing.T) {
+	b := &failingBackend{failures: 1}
+	i18n := New(b)
+	i18n.MissingPolicy = MissingRecord
+	i18n.T("en-US", "missing")
+
+	if err := i18n.FlushMissingTranslations(); err == nil {
+		t.Errorf("should return error if failed to save missing translations")
+	}
+
+	if missing := i18n.MissingTranslations(); len(missing) != 1 {
+		t.Errorf("missing translations failed to save should be kept, but got %v", len(missing))
+	}
+
+	if err := i18n.FlushMissingTranslations(); err != nil || len(b.saved) != 1 {
+		t.Errorf("should save missing translations in next flush, but got %v, %v", len(b.saved), err)
+	}
+}
+
+type existingBackend struct {
+	recordBackend
+	loads int
+}
+
+func (b *existingBackend) LoadTranslations() []*Translation {
+	b.loads++
+	return nil
+}
+
+func (b *existingBackend) FindTranslation(t *Translation) Translation {
+	if t.Key == "existing" {
+		return Translation{Key: t.Key, Locale: t.Locale, Value: "Existing"}
+	}
+	return Translation{}
+}
+
+func TestMissingFlushExisting(t *testing.T) {
+	b := &existingBackend{}
+	i18n := New(b)
+	i18n.MissingPolicy = MissingRecord
+	b.loads = 0
+
+	i18n.T("en-US", "existing")
+	i18n.T("en-US", "missing")
+
+	if err := i18n.FlushMissingTranslations(); err != nil || len(b.saved) != 1 || b.saved[0].Key != "missing" {
+		t.Errorf("should only save translations that don't exist, got %v, %v", err, b.saved)
+	}
+
+	if b.loads != 0 {
+		t.Errorf("should find pending translations instead of loading all translations, but loaded %v times", b.loads)
+	}
+
+	if value := i18n.T("en-US", "existing"); value != "Existing" {
+		t.Errorf("should add existing translation after flush, but got %v", value)
+	}
+
+	if seen := len(i18n.missingTranslations.seen); seen != 0 {
+		t.Errorf("should forget flushed missing translations, but got %v", seen)
+	}
+}
+
+func TestMissingLimit(t *testing.T) {
+	i18n := New(&backend{})
+	i18n.MissingPolicy = MissingRecord
+
+	for i := 0; i < maxMissingTranslations+10; i++ {
+		i18n.T("en-US", fmt.Sprintf("missing-%v", i))
+	}
+
+	if missing := i18n.MissingTranslations(); len(missing) != maxMissingTranslations {
+		t.Errorf("should record at most %v missing translations, but got %v", maxMissingTranslations, len(missing))
+	}
+}
@@ -0,0 +1,55 @@
+package i18n
+
+import (
+	"html/template"
+	"strings"
+)
+
+// contextSeparator separator between message context and key, same as gettext's `msgctxt` convention
+const contextSeparator = "\x04"
+
+// MessageKey return key of translation with message context, translations are indexed with it, e.g: `button\x04open`
+func MessageKey(msgctxt, key string) string {
+	if msgctxt == "" {
+		return key
+	}
+	return msgctxt + contextSeparator + key
+}
+
+// SplitMessageKey split key of translation with message context into message context and key
+func SplitMessageKey(messageKey string) (msgctxt, key string) {
+	if idx := strings.Index(messageKey, contextSeparator); idx != -1 {
+		return messageKey[:idx], messageKey[idx+len(contextSeparator):]
+	}
+	return "", messageKey
+}
+
+// messageKey return key of translation with its message context
+func (translation *Translation) messageKey() string {
+	return MessageKey(translation.Context, translation.Key)
+}
+
+// copy return a copy of translation, metadata is copied too, so translations returned by backends could be changed by callers
+func (translation *Translation) copy() *Translation {
+	result := *translation
+	if translation.Meta != nil {
+		meta := *translation.Meta
+		result.Meta = &meta
+	}
+	return &result
+}
+
+// Context return I18n that translates keys in message context, it is used to disambiguate identical keys, e.g: "open" as a verb or an adjective
+//
+//	I18n.Context("button").T("zh-CN", "open") // 打开
+//	I18n.Context("status").T("zh-CN", "open") // 营业中
+func (i18n *I18n) Context(msgctxt string) *I18n {
+	result := i18n.clone()
+	result.msgctxt = msgctxt
+	return result
+}
+
+// TC translate with locale, message context, key and arguments
+func (i18n *I18n) TC(locale, msgctxt, key string, args ...interface{}) template.HTML {
+	return i18n.Context(msgctxt).T(locale, key, args...)
+}
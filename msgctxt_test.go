@@ -0,0 +1,60 @@
+package i18n
+
+import "testing"
+
+func TestMessageContext(t *testing.T) {
+	i18n := New(&backend{})
+	i18n.AddTranslation(&Translation{Key: "shop.open", Locale: "en-US", Value: "Open"})
+	i18n.AddTranslation(&Translation{Key: "shop.open", Locale: "zh-CN", Value: "打开", Context: "button"})
+	i18n.AddTranslation(&Translation{Key: "shop.open", Locale: "zh-CN", Value: "营业中", Context: "status"})
+
+	if value := i18n.TC("zh-CN", "button", "shop.open"); value != "打开" {
+		t.Errorf("should translate with context button, but got %v", value)
+	}
+
+	if value := i18n.Context("status").Scope("shop").(*I18n).T("zh-CN", "open"); value != "营业中" {
+		t.Errorf("should translate with context and scope, but got %v", value)
+	}
+
+	if value := i18n.T("zh-CN", "shop.open"); value != "Open" {
+		t.Errorf("translations with context shouldn't be used without context, but got %v", value)
+	}
+
+	if result, ok := i18n.Context("menu").Lookup("zh-CN", "shop.open"); ok || result.Context != "menu" {
+		t.Errorf("translations without context shouldn't be used for context, but got %#v", result)
+	}
+
+	if results := i18n.Context("button").Namespace("zh-CN", "shop."); len(results) != 1 || results["shop.open"] != "打开" {
+		t.Errorf("namespace should only include translations of context, but got %v", results)
+	}
+
+	i18n.removeTranslation(&Translation{Key: "shop.open", Locale: "zh-CN", Context: "button"})
+	if value := i18n.TC("zh-CN", "status", "shop.open"); value != "营业中" {
+		t.Errorf("translations of other contexts shouldn't be removed, but got %v", value)
+	}
+}
+
+func TestMessageKey(t *testing.T) {
+	if msgctxt, key := SplitMessageKey(MessageKey("button", "open")); msgctxt != "button" || key != "open" {
+		t.Errorf("should split message key, but got %v, %v", msgctxt, key)
+	}
+
+	if key := MessageKey("", "open"); key != "open" {
+		t.Errorf("message key without context should be the key, but got %v", key)
+	}
+}
+
+func TestMessageContextEditable(t *testing.T) {
+	var (
+		files = &readOnlyBackend{translations: []*Translation{{Key: "open", Locale: "en-US", Value: "Open", Context: "button"}}}
+		i18n  = New(files, &memoryBackend{translations: map[string]*Translation{}})
+	)
+
+	if i18n.Context("button").Editable("en-US", "open") {
+		t.Errorf("translation of read-only backend shouldn't be editable")
+	}
+
+	if !i18n.Editable("en-US", "open") || !i18n.Context("status").Editable("en-US", "open") {
+		t.Errorf("translations of other contexts should be editable")
+	}
+}
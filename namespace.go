@@ -0,0 +1,113 @@
+package i18n
+
+import (
+	"crypto/sha1"
+	"encoding/json"
+	"fmt"
+	"html/template"
+	"net/http"
+	"strings"
+)
+
+// Namespace return resolved translation values of keys under prefix for locale, fallback locales are resolved for each key, e.g:
+//
+//	I18n.Namespace("zh-CN", "checkout.") // => map[string]string{"checkout.title": "结账", "checkout.submit": "Submit"}
+//
+// keys are relative to the scope of i18n, only translations in the message context of i18n are included, values are not formatted
+func (i18n *I18n) Namespace(locale, prefix string) map[string]string {
+	if locale == "" {
+		locale = Default
+	}
+
+	var locales = append([]string{locale}, i18n.getFallbackLocales(locale)...)
+	for _, l := range locales {
+		i18n.ensureLocale(l)
+	}
+
+	var (
+		results     = map[string]string{}
+		scopePrefix string
+	)
+
+	if i18n.scope != "" {
+		scopePrefix = i18n.scope + "."
+	}
+
+	// read keys from current snapshot without merging its overlay, so requests won't copy all translations
+	for _, l := range locales {
+		i18n.snapshot.rangeKeys(l, func(messageKey string) {
+			msgctxt, key := SplitMessageKey(messageKey)
+			if msgctxt != i18n.msgctxt || !strings.HasPrefix(key, scopePrefix+prefix) {
+				return
+			}
+
+			key = strings.TrimPrefix(key, scopePrefix)
+			if _, ok := results[key]; ok {
+				return
+			}
+
+			if result, ok := i18n.Lookup(locale, key); ok {
+				results[key] = result.Value
+			}
+		})
+	}
+
+	return results
+}
+
+// TMany translate keys with locale, return translations as map `map[key]translation`
+func (i18n *I18n) TMany(locale string, keys ...string) map[string]template.HTML {
+	var results = make(map[string]template.HTML, len(keys))
+	for _, key := range keys {
+		results[key] = i18n.T(locale, key)
+	}
+	return results
+}
+
+// NamespaceHandler return a http handler that serves namespace of prefix as JSON, if prefix is blank, it will be read from query param `prefix`
+// locale is read from query param `locale`, or request's context (see Middleware)
+// ETag is the hash of content, response is cacheable for a long time if query param `v` matches the hash, e.g: `/i18n.json?locale=zh-CN&v=<hash>`
+func (i18n *I18n) NamespaceHandler(prefix string) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		var (
+			query  = req.URL.Query()
+			locale = query.Get("locale")
+			p      = prefix
+		)
+
+		if locale == "" {
+			locale = LocaleFromContext(req.Context())
+		}
+
+		if p == "" {
+			p = query.Get("prefix")
+		}
+
+		data, err := json.Marshal(i18n.Namespace(locale, p))
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+
+		hash := fmt.Sprintf("%x", sha1.Sum(data))
+		w.Header().Set("ETag", `"`+hash+`"`)
+		w.Header().Set("Vary", "Accept-Language, Cookie")
+		if query.Get("v") == hash {
+			w.Header().Set("Cache-Control", "public, max-age=31536000, immutable")
+		} else {
+			w.Header().Set("Cache-Control", "no-cache")
+		}
+
+		if match := req.Header.Get("If-None-Match"); match != "" {
+			for _, etag := range strings.Split(match, ",") {
+				if etag = strings.TrimPrefix(strings.TrimSpace(etag), "W/"); etag == `"`+hash+`"` || etag == "*" {
+					w.WriteHeader(http.StatusNotModified)
+					return
+				}
+			}
+		}
+
+		w.Header().Set("Content-Type", "application/json; charset=utf-8")
+		w.Write(data)
+	})
+}
@@ -0,0 +1,112 @@
+package i18n
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+type loadBackend struct {
+	backend
+	translations []*Translation
+}
+
+func (b *loadBackend) LoadTranslations() []*Translation {
+	return b.translations
+}
+
+func newNamespaceI18n() *I18n {
+	return New(&loadBackend{translations: []*Translation{
+		{Key: "checkout.title", Locale: "en-US", Value: "Checkout"},
+		{Key: "checkout.submit", Locale: "en-US", Value: "Submit"},
+		{Key: "checkout.cancel", Locale: "en-US", Value: "Cancel"},
+		{Key: "home.title", Locale: "en-US", Value: "Home"},
+		{Key: "checkout.title", Locale: "zh-CN", Value: "结账"},
+		{Key: "checkout.cancel", Locale: "zh-TW", Value: "取消"},
+	}})
+}
+
+func TestNamespace(t *testing.T) {
+	i18n := newNamespaceI18n()
+	i18n.FallbackLocales = map[string][]string{"zh-CN": {"zh-TW"}}
+
+	namespace := i18n.Namespace("zh-CN", "checkout.")
+	expected := map[string]string{"checkout.title": "结账", "checkout.submit": "Submit", "checkout.cancel": "取消"}
+	if len(namespace) != len(expected) {
+		t.Errorf("expect namespace %v, but got %v", expected, namespace)
+	}
+	for key, value := range expected {
+		if namespace[key] != value {
+			t.Errorf("expect %v of namespace is %v, but got %v", key, value, namespace[key])
+		}
+	}
+
+	scoped := i18n.Scope("checkout").(*I18n).Namespace("zh-CN", "")
+	if len(scoped) != 3 || scoped["title"] != "结账" {
+		t.Errorf("namespace keys should be relative to scope, but got %v", scoped)
+	}
+}
+
+func TestNamespaceWithoutMergingOverlay(t *testing.T) {
+	i18n := newNamespaceI18n()
+	i18n.AddTranslation(&Translation{Key: "checkout.total", Locale: "en-US", Value: "Total"})
+	i18n.DeleteTranslation(&Translation{Key: "checkout.cancel", Locale: "en-US"})
+
+	namespace := i18n.Namespace("en-US", "checkout.")
+	if len(namespace) != 3 || namespace["checkout.total"] != "Total" {
+		t.Errorf("should include changes of overlay, but got %v", namespace)
+	}
+
+	if changes := i18n.snapshot.data().changes; changes == 0 {
+		t.Errorf("overlay shouldn't be merged by namespace")
+	}
+}
+
+func TestTMany(t *testing.T) {
+	i18n := newNamespaceI18n()
+	i18n.MissingPolicy = MissingOff
+
+	results := i18n.TMany("zh-CN", "checkout.title", "checkout.submit", "missing")
+	if results["checkout.title"] != "结账" || results["checkout.submit"] != "Submit" || results["missing"] != "missing" {
+		t.Errorf("failed to translate many keys, got %v", results)
+	}
+}
+
+func TestNamespaceHandler(t *testing.T) {
+	handler := newNamespaceI18n().NamespaceHandler("checkout.")
+
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, httptest.NewRequest("GET", "/i18n.json?locale=zh-CN", nil))
+
+	var results map[string]string
+	if err := json.Unmarshal(w.Body.Bytes(), &results); err != nil || results["checkout.title"] != "结账" || len(results) != 3 {
+		t.Errorf("failed to serve namespace, got %v (%v)", w.Body.String(), err)
+	}
+
+	etag := w.Header().Get("ETag")
+	if etag == "" || w.Header().Get("Cache-Control") != "no-cache" {
+		t.Errorf("should set etag and cache control, got %v", w.Header())
+	}
+
+	req := httptest.NewRequest("GET", "/i18n.json?locale=zh-CN", nil)
+	req.Header.Set("If-None-Match", etag)
+	w = httptest.NewRecorder()
+	handler.ServeHTTP(w, req)
+	if w.Code != http.StatusNotModified {
+		t.Errorf("should return not modified if etag matches, but got %v", w.Code)
+	}
+
+	w = httptest.NewRecorder()
+	handler.ServeHTTP(w, httptest.NewRequest("GET", "/i18n.json?locale=zh-CN&v="+strings.Trim(etag, `"`), nil))
+	if !strings.Contains(w.Header().Get("Cache-Control"), "immutable") {
+		t.Errorf("should be cached for a long time if version matches, but got %v", w.Header().Get("Cache-Control"))
+	}
+
+	w = httptest.NewRecorder()
+	handler.ServeHTTP(w, httptest.NewRequest("GET", "/i18n.json?locale=en-US", nil))
+	if w.Header().Get("ETag") == etag {
+		t.Errorf("etag should be changed for different content")
+	}
+}
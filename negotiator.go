@@ -0,0 +1,83 @@
+package i18n
+
+import (
+	"sort"
+
+	"golang.org/x/text/language"
+)
+
+// Negotiator match requested locales against available locales with BCP 47 matching, e.g: `de-AT` => `de-DE`, `zh-Hant-TW` => `zh-TW`
+type Negotiator struct {
+	locales []string
+	matcher language.Matcher
+}
+
+// NewNegotiator initialize negotiator with available locales, the first one will be used if nothing matched
+func NewNegotiator(locales ...string) *Negotiator {
+	var (
+		negotiator = &Negotiator{}
+		tags       []language.Tag
+	)
+
+	for _, locale := range locales {
+		if tag, err := language.Parse(locale); err == nil {
+			tags = append(tags, tag)
+			negotiator.locales = append(negotiator.locales, locale)
+		}
+	}
+
+	negotiator.matcher = language.NewMatcher(tags)
+	return negotiator
+}
+
+// Locales return available locales of negotiator
+func (negotiator *Negotiator) Locales() []string {
+	return negotiator.locales
+}
+
+// Match return best matched available locale for requested locales (ordered by preference) and its confidence
+func (negotiator *Negotiator) Match(locales ...string) (string, language.Confidence) {
+	var tags []language.Tag
+	for _, locale := range locales {
+		if tag, err := language.Parse(locale); err == nil {
+			tags = append(tags, tag)
+		}
+	}
+	return negotiator.match(tags...)
+}
+
+// MatchAcceptLanguage return best matched available locale for `Accept-Language` header and its confidence
+func (negotiator *Negotiator) MatchAcceptLanguage(acceptLanguage string) (string, language.Confidence) {
+	tags, _, _ := language.ParseAcceptLanguage(acceptLanguage)
+	return negotiator.match(tags...)
+}
+
+func (negotiator *Negotiator) match(tags ...language.Tag) (string, language.Confidence) {
+	if len(negotiator.locales) == 0 {
+		return "", language.No
+	}
+
+	_, index, confidence := negotiator.matcher.Match(tags...)
+	return negotiator.locales[index], confidence
+}
+
+// AvailableLocales return locales that have translations in backends
+func (i18n *I18n) AvailableLocales() []string {
+	var locales []string
+	for locale := range i18n.LoadTranslations() {
+		locales = append(locales, locale)
+	}
+	sort.Strings(locales)
+	return locales
+}
+
+// NewNegotiator initialize negotiator with available locales of backends, `Default` locale will be used if nothing matched
+func (i18n *I18n) NewNegotiator() *Negotiator {
+	var locales = []string{Default}
+	for _, locale := range i18n.AvailableLocales() {
+		if locale != Default {
+			locales = append(locales, locale)
+		}
+	}
+	return NewNegotiator(locales...)
+}
@@ -0,0 +1,40 @@
+package i18n
+
+import (
+	"testing"
+
+	"golang.org/x/text/language"
+)
+
+type negotiatorTestCase struct {
+	AcceptLanguage   string
+	ExpectLocale     string
+	ExpectConfidence language.Confidence
+}
+
+func TestNegotiator(t *testing.T) {
+	negotiator := NewNegotiator("en-US", "de-DE", "zh-CN", "zh-TW", "fr")
+
+	testCases := []negotiatorTestCase{
+		{AcceptLanguage: "de-DE", ExpectLocale: "de-DE", ExpectConfidence: language.Exact},
+		{AcceptLanguage: "de-AT", ExpectLocale: "de-DE", ExpectConfidence: language.High},
+		{AcceptLanguage: "zh-Hant-TW", ExpectLocale: "zh-TW", ExpectConfidence: language.Exact},
+		{AcceptLanguage: "ja-JP;q=0.9, fr-CA;q=0.8", ExpectLocale: "fr", ExpectConfidence: language.High},
+		{AcceptLanguage: "ja-JP", ExpectLocale: "en-US", ExpectConfidence: language.No},
+	}
+
+	for i, testCase := range testCases {
+		locale, confidence := negotiator.MatchAcceptLanguage(testCase.AcceptLanguage)
+		if locale != testCase.ExpectLocale || confidence != testCase.ExpectConfidence {
+			t.Errorf("#%d: expect %v (%v), but got %v (%v)", i+1, testCase.ExpectLocale, testCase.ExpectConfidence, locale, confidence)
+		}
+	}
+}
+
+func TestI18nNegotiator(t *testing.T) {
+	i18n := New(&backend{})
+	negotiator := i18n.NewNegotiator()
+	if locale, confidence := negotiator.Match("en-GB"); locale != Default || confidence == language.No {
+		t.Errorf("should match default locale, but got %v (%v)", locale, confidence)
+	}
+}
@@ -0,0 +1,146 @@
+package i18n
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"sync"
+)
+
+// ChangeEvent event of translation changes, it is published to other nodes with notifier
+type ChangeEvent struct {
+	// Locale, Key changed translation, translations of locale should be reloaded if key is blank, all translations should be reloaded if both are blank
+	Locale string
+	Key    string
+	// Context message context of changed translation
+	Context string
+	// Deleted translation is deleted
+	Deleted bool
+	// Source id of node that published the event
+	Source string
+}
+
+// Notifier publish and subscribe translation change events, used to invalidate translations of other nodes
+type Notifier interface {
+	Publish(event ChangeEvent) error
+	Subscribe(handler func(ChangeEvent)) (unsubscribe func(), err error)
+}
+
+// UseNotifier publish changes of SaveTranslation and DeleteTranslation with notifier, and update translations when received events from other nodes
+func (i18n *I18n) UseNotifier(notifier Notifier) error {
+	if i18n.nodeID == "" {
+		var id = make([]byte, 8)
+		rand.Read(id)
+		i18n.nodeID = hex.EncodeToString(id)
+	}
+
+	unsubscribe, err := notifier.Subscribe(i18n.handleChangeEvent)
+	if err == nil {
+		if i18n.unsubscribe != nil {
+			i18n.unsubscribe()
+		}
+		i18n.notifier = notifier
+		i18n.unsubscribe = unsubscribe
+	}
+	return err
+}
+
+// publish publish change event with notifier
+func (i18n *I18n) publish(event ChangeEvent) error {
+	if i18n.notifier == nil {
+		return nil
+	}
+	event.Source = i18n.nodeID
+	return i18n.notifier.Publish(event)
+}
+
+// handleChangeEvent update translation in snapshot for change events from other nodes
+func (i18n *I18n) handleChangeEvent(event ChangeEvent) {
+	if event.Source == i18n.nodeID {
+		return
+	}
+
+	if event.Key == "" {
+		if event.Locale == "" {
+			i18n.Reload()
+		} else {
+			i18n.reloadLocale(context.Background(), event.Locale)
+		}
+		return
+	}
+
+	if !event.Deleted {
+		i18n.refreshTranslation(&Translation{Locale: event.Locale, Key: event.Key, Context: event.Context})
+		return
+	}
+	i18n.removeTranslation(&Translation{Locale: event.Locale, Key: event.Key, Context: event.Context})
+}
+
+// RefreshTranslations update translations from backends after they are changed in backends directly, e.g: restored from versions,
+// translations that don't exist in backends anymore are removed, other nodes are notified with notifier
+func (i18n *I18n) RefreshTranslations(translations ...*Translation) (err error) {
+	for _, translation := range translations {
+		found, e := i18n.refreshTranslation(translation)
+		if e == nil {
+			e = i18n.publish(ChangeEvent{Locale: translation.Locale, Key: translation.Key, Context: translation.Context, Deleted: !found})
+		}
+		if e != nil {
+			err = e
+		}
+	}
+	return err
+}
+
+// refreshTranslation update translation from its first backend that has it, remove it if no backends have it
+func (i18n *I18n) refreshTranslation(translation *Translation) (bool, error) {
+	for _, backend := range i18n.Backends {
+		if result, ok, _ := findTranslation(context.Background(), backend, &Translation{Locale: translation.Locale, Key: translation.Key, Context: translation.Context}); ok {
+			result.Backend = backend
+			return true, i18n.AddTranslation(&result)
+		}
+	}
+	return false, i18n.removeTranslation(translation)
+}
+
+// MemoryNotifier in-memory notifier, it could be used in tests or to notify multiple I18n instances in one process
+type MemoryNotifier struct {
+	mutex    sync.RWMutex
+	handlers map[int]func(ChangeEvent)
+	nextID   int
+}
+
+// NewMemoryNotifier initialize in-memory notifier
+func NewMemoryNotifier() *MemoryNotifier {
+	return &MemoryNotifier{handlers: map[int]func(ChangeEvent){}}
+}
+
+// Publish call subscribed handlers synchronously
+func (notifier *MemoryNotifier) Publish(event ChangeEvent) error {
+	notifier.mutex.RLock()
+	var handlers = make([]func(ChangeEvent), 0, len(notifier.handlers))
+	for _, handler := range notifier.handlers {
+		handlers = append(handlers, handler)
+	}
+	notifier.mutex.RUnlock()
+
+	for _, handler := range handlers {
+		handler(event)
+	}
+	return nil
+}
+
+// Subscribe subscribe change events
+func (notifier *MemoryNotifier) Subscribe(handler func(ChangeEvent)) (func(), error) {
+	notifier.mutex.Lock()
+	defer notifier.mutex.Unlock()
+
+	id := notifier.nextID
+	notifier.nextID++
+	notifier.handlers[id] = handler
+
+	return func() {
+		notifier.mutex.Lock()
+		defer notifier.mutex.Unlock()
+		delete(notifier.handlers, id)
+	}, nil
+}
@@ -0,0 +1,81 @@
+package i18n
+
+import "testing"
+
+func TestNotifier(t *testing.T) {
+	var (
+		b        = &memoryBackend{translations: map[string]*Translation{}}
+		notifier = NewMemoryNotifier()
+		node1    = New(b)
+		node2    = New(b)
+	)
+
+	if err := node1.UseNotifier(notifier); err != nil {
+		t.Fatal(err)
+	}
+	if err := node2.UseNotifier(notifier); err != nil {
+		t.Fatal(err)
+	}
+
+	node1.SaveTranslation(&Translation{Key: "hello", Locale: "en-US", Value: "Hello"})
+	if result, ok := node2.Lookup("en-US", "hello"); !ok || result.Value != "Hello" || result.Backend != b {
+		t.Errorf("should update translation of other nodes, but got %v", result.Value)
+	}
+
+	node2.DeleteTranslation(&Translation{Key: "hello", Locale: "en-US"})
+	if _, ok := node1.Lookup("en-US", "hello"); ok {
+		t.Errorf("should delete translation of other nodes")
+	}
+
+	b.translations["en-US/bye"] = &Translation{Key: "bye", Locale: "en-US", Value: "Bye"}
+	notifier.Publish(ChangeEvent{})
+	if _, ok := node1.Lookup("en-US", "bye"); !ok {
+		t.Errorf("should reload translations for events without locale and key")
+	}
+
+	b.translations["en-US/bye"] = &Translation{Key: "bye", Locale: "en-US", Value: "Goodbye"}
+	b.translations["en-US/hi"] = &Translation{Key: "hi", Locale: "en-US", Value: "Hi"}
+	if err := node1.RefreshTranslations(&Translation{Key: "bye", Locale: "en-US"}, &Translation{Key: "hi", Locale: "en-US"}); err != nil {
+		t.Errorf("failed to refresh translations, got %v", err)
+	}
+
+	for _, node := range []*I18n{node1, node2} {
+		if result, _ := node.Lookup("en-US", "bye"); result.Value != "Goodbye" {
+			t.Errorf("should refresh changed translations of all nodes, but got %v", result.Value)
+		}
+	}
+
+	delete(b.translations, "en-US/hi")
+	node1.RefreshTranslations(&Translation{Key: "hi", Locale: "en-US"})
+	if _, ok := node2.Lookup("en-US", "hi"); ok {
+		t.Errorf("should remove refreshed translations that don't exist in backends")
+	}
+}
+
+type memoryBackend struct {
+	translations map[string]*Translation
+}
+
+func (b *memoryBackend) LoadTranslations() (translations []*Translation) {
+	for _, translation := range b.translations {
+		translations = append(translations, translation)
+	}
+	return translations
+}
+
+func (b *memoryBackend) SaveTranslation(t *Translation) error {
+	b.translations[cacheKey(t.Locale, t.Key)] = &Translation{Key: t.Key, Locale: t.Locale, Value: t.Value}
+	return nil
+}
+
+func (b *memoryBackend) FindTranslation(t *Translation) Translation {
+	if translation, ok := b.translations[cacheKey(t.Locale, t.Key)]; ok {
+		return *translation
+	}
+	return Translation{}
+}
+
+func (b *memoryBackend) DeleteTranslation(t *Translation) error {
+	delete(b.translations, cacheKey(t.Locale, t.Key))
+	return nil
+}
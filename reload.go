@@ -0,0 +1,149 @@
+package i18n
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// Watcher backends that could detect changes of translations, e.g: file changes, database notifications
+// Watch should call onChange when translations changed, and stop watching when stop is called
+type Watcher interface {
+	Watch(onChange func()) (stop func())
+}
+
+// Reload rebuild translations from all backends, translations of backends in the front have higher priority,
+// translations are loaded from all backends before swapping them atomically, so readers never see half-loaded translations,
+// changed translations will be written to cache store, and translations that don't exist in backends anymore will be removed,
+// translations added with AddTranslation without backends are kept if backends don't have them
+func (i18n *I18n) Reload() error {
+	return i18n.ReloadContext(context.Background())
+}
+
+// ReloadContext reload translations with context, translations won't be changed if failed to load translations from any backend
+// only loaded locales will be reloaded for lazy I18n
+func (i18n *I18n) ReloadContext(ctx context.Context) error {
+	i18n.snapshot.reload.Lock()
+	defer i18n.snapshot.reload.Unlock()
+
+	if i18n.lazy {
+		if err := i18n.listLocales(ctx); err != nil {
+			return err
+		}
+
+		if locales := i18n.loadedLocales(); len(locales) > 0 {
+			return i18n.load(ctx, locales)
+		}
+		return nil
+	}
+	return i18n.load(ctx, nil)
+}
+
+// reloadLocale reload translations of locale, locales that haven't been loaded are skipped for lazy I18n
+func (i18n *I18n) reloadLocale(ctx context.Context, locale string) error {
+	i18n.snapshot.reload.Lock()
+	defer i18n.snapshot.reload.Unlock()
+
+	if i18n.lazy {
+		if _, ok := i18n.locales.Load(locale); !ok {
+			return nil
+		}
+	}
+	return i18n.load(ctx, []string{locale})
+}
+
+// load load translations of locales from backends, all translations will be loaded if locales is nil
+func (i18n *I18n) load(ctx context.Context, locales []string) (err error) {
+	var translations = translationsSnapshot{}
+
+	// changes written while loading are applied to loaded translations
+	i18n.snapshot.beginReload()
+	for i := len(i18n.Backends) - 1; i >= 0; i-- {
+		var backend = i18n.Backends[i]
+		results, err := loadLocaleTranslations(ctx, backend, locales)
+		if err != nil {
+			i18n.snapshot.cancelReload()
+			return err
+		}
+
+		for _, translation := range results {
+			// translations might be shared by backends, e.g: cached translations, so they are copied instead of changed
+			cached := cachedTranslation{Translation: *translation, BackendIndex: i + 1}
+			cached.Backend = backend
+			if translations[translation.Locale] == nil {
+				translations[translation.Locale] = map[string]cachedTranslation{}
+			}
+			translations[translation.Locale][translation.messageKey()] = cached
+		}
+	}
+
+	var old translationsSnapshot
+	if locales == nil {
+		old = i18n.snapshot.swap(translations)
+	} else {
+		old = i18n.snapshot.replace(locales, translations)
+	}
+
+	for locale, values := range translations {
+		for key, translation := range values {
+			if o, ok := old[locale][key]; ok && o.Value == translation.Value && o.BackendIndex == translation.BackendIndex {
+				continue
+			}
+
+			i18n.invalidateCompiledTranslation(&translation.Translation)
+			if e := i18n.cacheStore.Set(cacheKey(locale, key), translation); e != nil {
+				err = e
+			}
+		}
+	}
+
+	for locale, values := range old {
+		for key, translation := range values {
+			if _, ok := translations[locale][key]; !ok {
+				i18n.invalidateCompiledTranslation(&translation.Translation)
+				i18n.cacheStore.Delete(cacheKey(locale, key))
+			}
+		}
+	}
+
+	return err
+}
+
+// AutoReload reload translations from backends periodically with interval, and reload translations when watchable backends changed, return a function to stop it
+func (i18n *I18n) AutoReload(interval time.Duration) (stop func()) {
+	var (
+		done     = make(chan struct{})
+		stopOnce sync.Once
+		stops    []func()
+	)
+
+	for _, backend := range i18n.Backends {
+		if watcher, ok := backend.(Watcher); ok {
+			stops = append(stops, watcher.Watch(func() { i18n.Reload() }))
+		}
+	}
+
+	if interval > 0 {
+		ticker := time.NewTicker(interval)
+		go func() {
+			defer ticker.Stop()
+			for {
+				select {
+				case <-ticker.C:
+					i18n.Reload()
+				case <-done:
+					return
+				}
+			}
+		}()
+	}
+
+	return func() {
+		stopOnce.Do(func() {
+			close(done)
+			for _, stop := range stops {
+				stop()
+			}
+		})
+	}
+}
@@ -0,0 +1,78 @@
+package i18n
+
+import (
+	"testing"
+	"time"
+)
+
+type watchBackend struct {
+	loadBackend
+	onChange func()
+	stopped  bool
+}
+
+func (b *watchBackend) Watch(onChange func()) func() {
+	b.onChange = onChange
+	return func() { b.stopped = true }
+}
+
+func TestReload(t *testing.T) {
+	b1 := &loadBackend{translations: []*Translation{{Key: "hello", Locale: "en-US", Value: "Hello"}}}
+	b2 := &loadBackend{translations: []*Translation{{Key: "hello", Locale: "en-US", Value: "Hi"}, {Key: "bye", Locale: "en-US", Value: "Bye"}}}
+	i18n := New(b1, b2)
+
+	if value := i18n.T("en-US", "hello"); value != "Hello" {
+		t.Errorf("translations of backends in the front should have higher priority, but got %v", value)
+	}
+
+	revision := i18n.Revision("en-US")
+	if err := i18n.Reload(); err != nil || i18n.Revision("en-US") != revision {
+		t.Errorf("revision shouldn't be changed if translations not changed, got %v", err)
+	}
+
+	b1.translations = []*Translation{{Key: "hello", Locale: "en-US", Value: "Hello World"}}
+	b2.translations = []*Translation{{Key: "hello", Locale: "en-US", Value: "Hi"}}
+	i18n.MissingPolicy = MissingOff
+	if err := i18n.Reload(); err != nil {
+		t.Errorf("failed to reload, got %v", err)
+	}
+
+	if value := i18n.T("en-US", "hello"); value != "Hello World" {
+		t.Errorf("should reload changed translation, but got %v", value)
+	}
+
+	if _, ok := i18n.Lookup("en-US", "bye"); ok {
+		t.Errorf("should remove translation that doesn't exist in backends anymore")
+	}
+
+	if i18n.Revision("en-US") == revision {
+		t.Errorf("revision should be changed after reloading changed translations")
+	}
+}
+
+func TestAutoReload(t *testing.T) {
+	b := &watchBackend{loadBackend: loadBackend{translations: []*Translation{{Key: "hello", Locale: "en-US", Value: "Hello"}}}}
+	i18n := New(b)
+
+	stop := i18n.AutoReload(0)
+	b.translations = []*Translation{{Key: "hello", Locale: "en-US", Value: "Hello World"}}
+	b.onChange()
+	if value := i18n.T("en-US", "hello"); value != "Hello World" {
+		t.Errorf("should reload when watcher notified changes, but got %v", value)
+	}
+
+	stop()
+	if !b.stopped {
+		t.Errorf("watcher should be stopped")
+	}
+
+	b.translations = []*Translation{{Key: "hello", Locale: "en-US", Value: "Hi"}}
+	stop = i18n.AutoReload(10 * time.Millisecond)
+	defer stop()
+	for i := 0; i < 100 && i18n.T("en-US", "hello") != "Hi"; i++ {
+		time.Sleep(5 * time.Millisecond)
+	}
+	if value := i18n.T("en-US", "hello"); value != "Hi" {
+		t.Errorf("should reload periodically, but got %v", value)
+	}
+}
@@ -0,0 +1,22 @@
+package i18n
+
+import "hash/fnv"
+
+// translationRevision content hash of translation, revision of a locale is the sum of hashes of its translations, so it doesn't depend on order and could be updated incrementally
+func translationRevision(key string, translation cachedTranslation) uint64 {
+	hash := fnv.New64a()
+	hash.Write([]byte(key))
+	hash.Write([]byte{0})
+	hash.Write([]byte(translation.Value))
+	return hash.Sum64()
+}
+
+// Revision return revision of locale, it is derived from content of the locale's translations, it changes when translations of the locale are added, saved or deleted,
+// nodes that serve the same translations have the same revision, even after restarting, could be used to invalidate caches of CDNs, client bundles or other nodes,
+// locale without translations has revision zero
+func (i18n *I18n) Revision(locale string) uint64 {
+	if locale == "" {
+		locale = Default
+	}
+	return i18n.snapshot.revision(locale)
+}
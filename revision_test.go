@@ -0,0 +1,53 @@
+package i18n
+
+import "testing"
+
+func TestRevision(t *testing.T) {
+	i18n := New(&loadBackend{translations: []*Translation{{Key: "hello", Locale: "en-US", Value: "Hello"}}})
+
+	revision := i18n.Revision("en-US")
+	if revision == 0 || i18n.Revision("") != revision {
+		t.Errorf("revision should be derived from loaded translations")
+	}
+
+	if i18n.Revision("zh-CN") != 0 {
+		t.Errorf("revision of locale without translations should be zero")
+	}
+
+	i18n.AddTranslation(&Translation{Key: "hello", Locale: "zh-CN", Value: "你好"})
+	added := i18n.Revision("zh-CN")
+	if added == 0 || i18n.Revision("en-US") != revision {
+		t.Errorf("revision should only be changed for changed locale")
+	}
+
+	i18n.SaveTranslation(&Translation{Key: "hello", Locale: "zh-CN", Value: "您好"})
+	if saved := i18n.Revision("zh-CN"); saved == added || saved == 0 {
+		t.Errorf("revision should be changed when saving translation")
+	}
+
+	i18n.AddTranslation(&Translation{Key: "hello", Locale: "zh-CN", Value: "你好"})
+	if i18n.Revision("zh-CN") != added {
+		t.Errorf("revision should be the same for the same translations")
+	}
+
+	i18n.Scope("home").(*I18n).DeleteTranslation(&Translation{Key: "hello", Locale: "zh-CN"})
+	if i18n.Revision("zh-CN") != 0 {
+		t.Errorf("revision should be changed when deleting translation, and shared with scoped i18n")
+	}
+}
+
+func TestRevisionOfNodes(t *testing.T) {
+	var (
+		node1 = New(&loadBackend{translations: []*Translation{{Key: "hello", Locale: "en-US", Value: "Hello"}, {Key: "bye", Locale: "en-US", Value: "Bye"}}})
+		node2 = New(&loadBackend{translations: []*Translation{{Key: "bye", Locale: "en-US", Value: "Bye"}}})
+	)
+
+	if node1.Revision("en-US") == node2.Revision("en-US") {
+		t.Errorf("revision should be different for different translations")
+	}
+
+	node2.AddTranslation(&Translation{Key: "hello", Locale: "en-US", Value: "Hello"})
+	if node1.Revision("en-US") != node2.Revision("en-US") {
+		t.Errorf("nodes with the same translations should have the same revision")
+	}
+}
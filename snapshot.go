@@ -0,0 +1,353 @@
+package i18n
+
+import (
+	"sync"
+	"sync/atomic"
+)
+
+// translationsSnapshot translations `map[locale]map[key]cachedTranslation`
+type translationsSnapshot map[string]map[string]cachedTranslation
+
+// snapshot translations snapshot used as read path of i18n, it is swapped atomically, so readers always see complete translations
+// changes of single translation are written to overlay of current snapshot, and merged into a new snapshot once there are too many changes or all translations are loaded
+type snapshot struct {
+	mutex  sync.Mutex
+	reload sync.Mutex
+	value  atomic.Value
+	// added translations added without backends, they are kept when reloading if backends don't have them
+	added map[overlayKey]cachedTranslation
+	// reloading changes written while reloading are recorded in pending, and applied to reloaded translations, so they won't be lost
+	reloading bool
+	pending   []pendingChange
+}
+
+type snapshotData struct {
+	// translations immutable translations
+	translations translationsSnapshot
+	size         int
+	// overlay changed translations since snapshot created, `map[overlayKey]cachedTranslation`, deleted translations are saved as nil
+	overlay sync.Map
+	changes int64
+	// locales locales of translations written to overlay
+	locales sync.Map
+	// revisions content hashes of locales when snapshot created, changes in overlay are added to revisionChanges, `map[locale]*uint64`
+	revisions       map[string]uint64
+	revisionChanges sync.Map
+}
+
+type overlayKey struct {
+	locale string
+	key    string
+}
+
+// pendingChange change written while reloading, translation is nil if it is deleted
+type pendingChange struct {
+	overlayKey
+	translation interface{}
+}
+
+func newSnapshotData(translations translationsSnapshot) *snapshotData {
+	data := &snapshotData{translations: translations, revisions: make(map[string]uint64, len(translations))}
+	for locale, values := range translations {
+		data.size += len(values)
+		for key, translation := range values {
+			data.revisions[locale] += translationRevision(key, translation)
+		}
+	}
+	return data
+}
+
+func (s *snapshot) data() *snapshotData {
+	if data, ok := s.value.Load().(*snapshotData); ok {
+		return data
+	}
+	return &snapshotData{}
+}
+
+func (s *snapshot) get(locale, key string) (cachedTranslation, bool) {
+	return s.data().get(locale, key)
+}
+
+func (data *snapshotData) get(locale, key string) (cachedTranslation, bool) {
+	if atomic.LoadInt64(&data.changes) > 0 {
+		if value, ok := data.overlay.Load(overlayKey{locale: locale, key: key}); ok {
+			translation, ok := value.(cachedTranslation)
+			return translation, ok
+		}
+	}
+
+	translation, ok := data.translations[locale][key]
+	return translation, ok
+}
+
+// hasLocale check if locale has translations in snapshot
+func (s *snapshot) hasLocale(locale string) bool {
+	data := s.data()
+	if _, ok := data.translations[locale]; ok {
+		return true
+	}
+	_, ok := data.locales.Load(locale)
+	return ok
+}
+
+// revision return content hash of locale, changes in overlay are included
+func (s *snapshot) revision(locale string) uint64 {
+	data := s.data()
+	revision := data.revisions[locale]
+	if changes, ok := data.revisionChanges.Load(locale); ok {
+		revision += atomic.LoadUint64(changes.(*uint64))
+	}
+	return revision
+}
+
+// rangeKeys call fn with keys of locale without merging overlay, keys deleted in overlay might be included
+func (s *snapshot) rangeKeys(locale string, fn func(key string)) {
+	data := s.data()
+	for key := range data.translations[locale] {
+		fn(key)
+	}
+
+	if atomic.LoadInt64(&data.changes) > 0 {
+		data.overlay.Range(func(k, value interface{}) bool {
+			if key := k.(overlayKey); key.locale == locale {
+				if _, ok := value.(cachedTranslation); ok {
+					fn(key.key)
+				}
+			}
+			return true
+		})
+	}
+}
+
+// load return all translations, changes in overlay are merged into a new snapshot, so they won't be merged again by next loading
+func (s *snapshot) load() translationsSnapshot {
+	data := s.data()
+	if atomic.LoadInt64(&data.changes) == 0 {
+		return data.translations
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	return s.compact().translations
+}
+
+// compact merge overlay into a new snapshot and store it, caller should hold the mutex
+func (s *snapshot) compact() *snapshotData {
+	data := s.data()
+	if atomic.LoadInt64(&data.changes) == 0 {
+		return data
+	}
+
+	merged := data.merge()
+	s.value.Store(merged)
+	return merged
+}
+
+// merge merge overlay into a new snapshot, only changed locales are copied, others are shared as translations are immutable
+func (data *snapshotData) merge() *snapshotData {
+	var (
+		translations = make(translationsSnapshot, len(data.translations))
+		copied       = map[string]bool{}
+		merged       = &snapshotData{translations: translations, size: data.size, revisions: make(map[string]uint64, len(data.revisions))}
+	)
+
+	for locale, values := range data.translations {
+		translations[locale] = values
+	}
+
+	for locale, revision := range data.revisions {
+		merged.revisions[locale] = revision
+	}
+
+	data.revisionChanges.Range(func(locale, changes interface{}) bool {
+		merged.revisions[locale.(string)] += atomic.LoadUint64(changes.(*uint64))
+		return true
+	})
+
+	data.overlay.Range(func(k, value interface{}) bool {
+		key := k.(overlayKey)
+		if !copied[key.locale] {
+			copied[key.locale] = true
+			values := make(map[string]cachedTranslation, len(translations[key.locale])+1)
+			for k, v := range translations[key.locale] {
+				values[k] = v
+			}
+			translations[key.locale] = values
+		}
+
+		_, existing := translations[key.locale][key.key]
+		if translation, ok := value.(cachedTranslation); ok {
+			translations[key.locale][key.key] = translation
+			if !existing {
+				merged.size++
+			}
+		} else if existing {
+			delete(translations[key.locale], key.key)
+			merged.size--
+		}
+		return true
+	})
+
+	for locale := range copied {
+		if len(translations[locale]) == 0 {
+			delete(translations, locale)
+			delete(merged.revisions, locale)
+		}
+	}
+	return merged
+}
+
+// write write translation into overlay, translation is deleted if it is nil
+func (s *snapshot) write(locale, key string, translation interface{}) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	data, ok := s.value.Load().(*snapshotData)
+	if !ok {
+		data = newSnapshotData(translationsSnapshot{})
+		s.value.Store(data)
+	}
+
+	k := overlayKey{locale: locale, key: key}
+	if t, ok := translation.(cachedTranslation); ok && t.BackendIndex == 0 {
+		if s.added == nil {
+			s.added = map[overlayKey]cachedTranslation{}
+		}
+		s.added[k] = t
+	} else {
+		delete(s.added, k)
+	}
+
+	if s.reloading {
+		s.pending = append(s.pending, pendingChange{overlayKey: k, translation: translation})
+	}
+
+	// update revision of locale with changed content hash
+	var change uint64
+	if old, ok := data.get(locale, key); ok {
+		change -= translationRevision(key, old)
+	}
+	if t, ok := translation.(cachedTranslation); ok {
+		change += translationRevision(key, t)
+	}
+	if changes, ok := data.revisionChanges.Load(locale); ok {
+		atomic.AddUint64(changes.(*uint64), change)
+	} else {
+		data.revisionChanges.Store(locale, &change)
+	}
+
+	data.overlay.Store(k, translation)
+	if translation != nil {
+		data.locales.Store(locale, true)
+	}
+
+	// merge overlay into new snapshot if there are too many changes, make sure it won't be merged for every change
+	if changes := atomic.AddInt64(&data.changes, 1); changes > int64(data.size/2+1024) {
+		s.compact()
+	}
+}
+
+func (s *snapshot) set(translation cachedTranslation) {
+	s.write(translation.Locale, translation.messageKey(), translation)
+}
+
+func (s *snapshot) delete(locale, key string) {
+	if _, ok := s.get(locale, key); ok {
+		s.write(locale, key, nil)
+	}
+}
+
+// beginReload record changes written while reloading, they will be applied to reloaded translations by swap or replace
+func (s *snapshot) beginReload() {
+	s.mutex.Lock()
+	s.reloading, s.pending = true, nil
+	s.mutex.Unlock()
+}
+
+// cancelReload stop recording changes if failed to reload
+func (s *snapshot) cancelReload() {
+	s.mutex.Lock()
+	s.reloading, s.pending = false, nil
+	s.mutex.Unlock()
+}
+
+// keep apply translations added without backends and changes written while reloading to reloaded translations of locales, caller should hold the mutex
+func (s *snapshot) keep(translations translationsSnapshot, loaded func(locale string) bool) {
+	var set = func(key overlayKey, translation cachedTranslation) {
+		if translations[key.locale] == nil {
+			translations[key.locale] = map[string]cachedTranslation{}
+		}
+		translations[key.locale][key.key] = translation
+	}
+
+	for key, translation := range s.added {
+		if _, ok := translations[key.locale][key.key]; !ok && loaded(key.locale) {
+			set(key, translation)
+		}
+	}
+
+	for _, change := range s.pending {
+		if !loaded(change.locale) {
+			continue
+		}
+
+		if translation, ok := change.translation.(cachedTranslation); ok {
+			set(change.overlayKey, translation)
+		} else {
+			delete(translations[change.locale], change.key)
+		}
+	}
+	s.reloading, s.pending = false, nil
+}
+
+// replace replace translations of locales, return old translations of locales
+func (s *snapshot) replace(locales []string, translations translationsSnapshot) translationsSnapshot {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	var (
+		current  = s.compact().translations
+		results  = make(translationsSnapshot, len(current)+len(locales))
+		old      = translationsSnapshot{}
+		replaced = map[string]bool{}
+	)
+
+	for _, locale := range locales {
+		replaced[locale] = true
+	}
+	s.keep(translations, func(locale string) bool { return replaced[locale] })
+
+	for locale, values := range current {
+		results[locale] = values
+	}
+
+	for _, locale := range locales {
+		if values, ok := current[locale]; ok {
+			old[locale] = values
+		}
+		delete(results, locale)
+		if values, ok := translations[locale]; ok && len(values) > 0 {
+			results[locale] = values
+		}
+	}
+
+	s.value.Store(newSnapshotData(results))
+	return old
+}
+
+// swap replace translations, return old translations
+func (s *snapshot) swap(translations translationsSnapshot) translationsSnapshot {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	old := s.compact().translations
+	s.keep(translations, func(string) bool { return true })
+	for locale, values := range translations {
+		if len(values) == 0 {
+			delete(translations, locale)
+		}
+	}
+
+	s.value.Store(newSnapshotData(translations))
+	return old
+}
@@ -0,0 +1,229 @@
+package i18n
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+)
+
+func TestSnapshot(t *testing.T) {
+	s := &snapshot{}
+	if _, ok := s.get("en-US", "hello"); ok {
+		t.Errorf("empty snapshot shouldn't have translations")
+	}
+
+	s.swap(translationsSnapshot{"en-US": {"hello": {Translation: Translation{Locale: "en-US", Key: "hello", Value: "Hello"}}}})
+	s.set(cachedTranslation{Translation: Translation{Locale: "zh-CN", Key: "hello", Value: "你好"}})
+	s.delete("en-US", "hello")
+
+	if _, ok := s.get("en-US", "hello"); ok {
+		t.Errorf("deleted translation should be removed from snapshot")
+	}
+
+	if translation, ok := s.get("zh-CN", "hello"); !ok || translation.Value != "你好" {
+		t.Errorf("should get translation from overlay, but got %v", translation)
+	}
+
+	translations := s.load()
+	if len(translations["en-US"]) != 0 || translations["zh-CN"]["hello"].Value != "你好" {
+		t.Errorf("should merge overlay when loading translations, but got %v", translations)
+	}
+
+	for i := 0; i < 2000; i++ {
+		s.set(cachedTranslation{Translation: Translation{Locale: "en-US", Key: fmt.Sprint(i), Value: fmt.Sprint(i)}})
+	}
+
+	if data := s.data(); data.size < 1000 || data.changes > int64(data.size/2+1024) {
+		t.Errorf("overlay should be merged into snapshot if there are too many changes, size: %v, changes: %v", data.size, data.changes)
+	}
+
+	if translation, ok := s.get("en-US", "1999"); !ok || translation.Value != "1999" {
+		t.Errorf("should get translation after merging, but got %v", translation)
+	}
+}
+
+func TestReloadAtomically(t *testing.T) {
+	var translations []*Translation
+	for i := 0; i < 1000; i++ {
+		translations = append(translations, &Translation{Key: fmt.Sprint(i), Locale: "en-US", Value: "v1"})
+	}
+	b := &loadBackend{translations: translations}
+	i18n := New(b)
+
+	var newTranslations []*Translation
+	for i := 0; i < 1000; i++ {
+		newTranslations = append(newTranslations, &Translation{Key: fmt.Sprint(i), Locale: "en-US", Value: "v2"})
+	}
+	b.translations = newTranslations
+
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		i18n.Reload()
+	}()
+
+	for j := 0; j < 100; j++ {
+		translations := i18n.snapshot.load()
+		first := translations["en-US"]["0"].Value
+		for _, translation := range translations["en-US"] {
+			if translation.Value != first {
+				t.Fatalf("readers should never see half-loaded translations")
+			}
+		}
+	}
+	wg.Wait()
+}
+
+func newBenchmarkI18n(locales int, keys int) *I18n {
+	var translations []*Translation
+	for l := 0; l < locales; l++ {
+		for k := 0; k < keys; k++ {
+			translations = append(translations, &Translation{Key: fmt.Sprintf("key-%d", k), Locale: fmt.Sprintf("locale-%d", l), Value: "value"})
+		}
+	}
+
+	i18n := New(&loadBackend{translations: translations})
+	i18n.FallbackLocales = map[string][]string{"missing": {"locale-1", "locale-0"}}
+	return i18n
+}
+
+// BenchmarkLookupCacheStore lookup translation with cache store, it is the read path before snapshot
+func BenchmarkLookupCacheStore(b *testing.B) {
+	i18n := newBenchmarkI18n(10, 1000)
+	locales := append([]string{"missing"}, i18n.getFallbackLocales("missing")...)
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		for _, l := range locales {
+			var translation cachedTranslation
+			if err := i18n.cacheStore.Unmarshal(cacheKey(l, "key-500"), &translation); err == nil && translation.Value != "" {
+				break
+			}
+		}
+	}
+}
+
+func BenchmarkLookupSnapshot(b *testing.B) {
+	i18n := newBenchmarkI18n(10, 1000)
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		i18n.Lookup("missing", "key-500")
+	}
+}
+
+func BenchmarkLookupSnapshotWithChanges(b *testing.B) {
+	i18n := newBenchmarkI18n(10, 1000)
+	i18n.AddTranslation(&Translation{Key: "key-1", Locale: "locale-0", Value: "changed"})
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		i18n.Lookup("missing", "key-500")
+	}
+}
+
+func BenchmarkAddTranslation(b *testing.B) {
+	i18n := newBenchmarkI18n(10, 1000)
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		i18n.AddTranslation(&Translation{Key: fmt.Sprintf("key-%d", i%1000), Locale: "locale-0", Value: "changed"})
+	}
+}
+
+func BenchmarkReload(b *testing.B) {
+	i18n := newBenchmarkI18n(10, 1000)
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		i18n.Reload()
+	}
+}
+
+func TestSnapshotLoadMergesOverlay(t *testing.T) {
+	s := &snapshot{}
+	s.swap(translationsSnapshot{
+		"en-US": {"hello": {Translation: Translation{Locale: "en-US", Key: "hello", Value: "Hello"}}},
+		"zh-CN": {"hello": {Translation: Translation{Locale: "zh-CN", Key: "hello", Value: "你好"}}},
+	})
+	zhCN := s.load()["zh-CN"]
+
+	s.set(cachedTranslation{Translation: Translation{Locale: "en-US", Key: "bye", Value: "Bye"}})
+	translations := s.load()
+	if translations["en-US"]["bye"].Value != "Bye" || len(translations["en-US"]) != 2 {
+		t.Errorf("should merge overlay when loading translations, but got %v", translations)
+	}
+
+	if data := s.data(); data.changes != 0 || data.size != 3 {
+		t.Errorf("overlay should be merged into snapshot when loading, changes: %v, size: %v", data.changes, data.size)
+	}
+
+	if fmt.Sprintf("%p", translations["zh-CN"]) != fmt.Sprintf("%p", zhCN) {
+		t.Errorf("unchanged locales should not be copied when merging overlay")
+	}
+}
+
+func TestReloadKeepsAddedTranslations(t *testing.T) {
+	b := &loadBackend{translations: []*Translation{{Key: "hello", Locale: "en-US", Value: "Hello"}}}
+	i18n := New(b)
+	i18n.AddTranslation(&Translation{Key: "added", Locale: "en-US", Value: "Added"})
+	i18n.AddTranslation(&Translation{Key: "hello", Locale: "zh-CN", Value: "你好"})
+
+	if err := i18n.Reload(); err != nil {
+		t.Fatalf("failed to reload, got %v", err)
+	}
+
+	if value := i18n.T("en-US", "added"); value != "Added" {
+		t.Errorf("should keep translations added without backends when reloading, but got %v", value)
+	}
+
+	if value := i18n.T("zh-CN", "hello"); value != "你好" {
+		t.Errorf("should keep translations added without backends when reloading, but got %v", value)
+	}
+
+	b.translations = append(b.translations, &Translation{Key: "added", Locale: "en-US", Value: "From Backend"})
+	i18n.Reload()
+	if value := i18n.T("en-US", "added"); value != "From Backend" {
+		t.Errorf("translations of backends should override added translations, but got %v", value)
+	}
+}
+
+func TestReloadKeepsConcurrentChanges(t *testing.T) {
+	b := &blockingBackend{loadBackend: loadBackend{translations: []*Translation{{Key: "hello", Locale: "en-US", Value: "Hello"}}}}
+	i18n := New(b)
+
+	loading, release := make(chan struct{}), make(chan struct{})
+	b.loading, b.release = loading, release
+
+	done := make(chan error)
+	go func() { done <- i18n.Reload() }()
+
+	<-loading
+	i18n.AddTranslation(&Translation{Key: "hello", Locale: "en-US", Value: "Hello World", Backend: b})
+	close(release)
+
+	if err := <-done; err != nil {
+		t.Fatalf("failed to reload, got %v", err)
+	}
+
+	if value := i18n.T("en-US", "hello"); value != "Hello World" {
+		t.Errorf("changes written while reloading should not be lost, but got %v", value)
+	}
+}
+
+// blockingBackend backend that blocks loading until released
+type blockingBackend struct {
+	loadBackend
+	loading chan struct{}
+	release chan struct{}
+}
+
+func (b *blockingBackend) LoadTranslations() []*Translation {
+	if b.loading != nil {
+		close(b.loading)
+		<-b.release
+		b.loading = nil
+	}
+	return b.loadBackend.LoadTranslations()
+}
@@ -0,0 +1,148 @@
+// tables.go CLDR data used by helpers of TemplateFormatter, it is maintained by hand and only has data of
+// und, en, en-GB, de, fr, es, it, ja, zh and currency symbol positions of some european locales, add locales here when needed
+
+package i18n
+
+// currencySymbolSuffixes whether locales put currency symbol after amount, locales without data use data of parent locales
+var currencySymbolSuffixes = map[string]bool{
+	"und": false, "cs": true, "da": true, "de": true, "de-CH": false, "es": true, "fi": true, "fr": true, "it": true,
+	"nb": true, "pl": true, "pt-PT": true, "ru": true, "sk": true, "sv": true, "uk": true,
+}
+
+var (
+	englishMonths      = []string{"January", "February", "March", "April", "May", "June", "July", "August", "September", "October", "November", "December"}
+	englishShortMonths = []string{"Jan", "Feb", "Mar", "Apr", "May", "Jun", "Jul", "Aug", "Sep", "Oct", "Nov", "Dec"}
+	englishWeekdays    = []string{"Sunday", "Monday", "Tuesday", "Wednesday", "Thursday", "Friday", "Saturday"}
+	cjkMonths          = []string{"1月", "2月", "3月", "4月", "5月", "6月", "7月", "8月", "9月", "10月", "11月", "12月"}
+	twentyFourHours    = map[string]string{"short": "HH:mm", "medium": "HH:mm:ss", "long": "HH:mm:ss z", "full": "HH:mm:ss z"}
+)
+
+// dateTimeFormats date, time patterns and names of locales, locales without data use data of parent locales
+var dateTimeFormats = map[string]*dateTimeFormat{
+	"und": {
+		date:        map[string]string{"short": "y-MM-dd", "medium": "y MMM d", "long": "y MMMM d", "full": "y MMMM d, EEEE"},
+		time:        map[string]string{"short": "HH:mm", "medium": "HH:mm:ss", "long": "HH:mm:ss z", "full": "HH:mm:ss zzzz"},
+		months:      []string{"M01", "M02", "M03", "M04", "M05", "M06", "M07", "M08", "M09", "M10", "M11", "M12"},
+		shortMonths: []string{"M01", "M02", "M03", "M04", "M05", "M06", "M07", "M08", "M09", "M10", "M11", "M12"},
+		weekdays:    []string{"Sun", "Mon", "Tue", "Wed", "Thu", "Fri", "Sat"},
+		am:          "AM", pm: "PM",
+	},
+	"en": {
+		date:        map[string]string{"short": "M/d/yy", "medium": "MMM d, y", "long": "MMMM d, y", "full": "EEEE, MMMM d, y"},
+		time:        map[string]string{"short": "h:mm a", "medium": "h:mm:ss a", "long": "h:mm:ss a z", "full": "h:mm:ss a z"},
+		months:      englishMonths,
+		shortMonths: englishShortMonths,
+		weekdays:    englishWeekdays,
+		am:          "AM", pm: "PM",
+	},
+	"en-GB": {
+		date:        map[string]string{"short": "dd/MM/y", "medium": "d MMM y", "long": "d MMMM y", "full": "EEEE d MMMM y"},
+		time:        twentyFourHours,
+		months:      englishMonths,
+		shortMonths: englishShortMonths,
+		weekdays:    englishWeekdays,
+	},
+	"de": {
+		date:        map[string]string{"short": "dd.MM.yy", "medium": "dd.MM.y", "long": "d. MMMM y", "full": "EEEE, d. MMMM y"},
+		time:        twentyFourHours,
+		months:      []string{"Januar", "Februar", "März", "April", "Mai", "Juni", "Juli", "August", "September", "Oktober", "November", "Dezember"},
+		shortMonths: []string{"Jan.", "Feb.", "März", "Apr.", "Mai", "Juni", "Juli", "Aug.", "Sept.", "Okt.", "Nov.", "Dez."},
+		weekdays:    []string{"Sonntag", "Montag", "Dienstag", "Mittwoch", "Donnerstag", "Freitag", "Samstag"},
+	},
+	"fr": {
+		date:        map[string]string{"short": "dd/MM/y", "medium": "d MMM y", "long": "d MMMM y", "full": "EEEE d MMMM y"},
+		time:        twentyFourHours,
+		months:      []string{"janvier", "février", "mars", "avril", "mai", "juin", "juillet", "août", "septembre", "octobre", "novembre", "décembre"},
+		shortMonths: []string{"janv.", "févr.", "mars", "avr.", "mai", "juin", "juil.", "août", "sept.", "oct.", "nov.", "déc."},
+		weekdays:    []string{"dimanche", "lundi", "mardi", "mercredi", "jeudi", "vendredi", "samedi"},
+	},
+	"es": {
+		date:        map[string]string{"short": "d/M/yy", "medium": "d MMM y", "long": "d 'de' MMMM 'de' y", "full": "EEEE, d 'de' MMMM 'de' y"},
+		time:        map[string]string{"short": "H:mm", "medium": "H:mm:ss", "long": "H:mm:ss z", "full": "H:mm:ss z"},
+		months:      []string{"enero", "febrero", "marzo", "abril", "mayo", "junio", "julio", "agosto", "septiembre", "octubre", "noviembre", "diciembre"},
+		shortMonths: []string{"ene", "feb", "mar", "abr", "may", "jun", "jul", "ago", "sept", "oct", "nov", "dic"},
+		weekdays:    []string{"domingo", "lunes", "martes", "miércoles", "jueves", "viernes", "sábado"},
+	},
+	"it": {
+		date:        map[string]string{"short": "dd/MM/yy", "medium": "d MMM y", "long": "d MMMM y", "full": "EEEE d MMMM y"},
+		time:        twentyFourHours,
+		months:      []string{"gennaio", "febbraio", "marzo", "aprile", "maggio", "giugno", "luglio", "agosto", "settembre", "ottobre", "novembre", "dicembre"},
+		shortMonths: []string{"gen", "feb", "mar", "apr", "mag", "giu", "lug", "ago", "set", "ott", "nov", "dic"},
+		weekdays:    []string{"domenica", "lunedì", "martedì", "mercoledì", "giovedì", "venerdì", "sabato"},
+	},
+	"ja": {
+		date:        map[string]string{"short": "y/MM/dd", "medium": "y/MM/dd", "long": "y年M月d日", "full": "y年M月d日EEEE"},
+		time:        map[string]string{"short": "H:mm", "medium": "H:mm:ss", "long": "H:mm:ss z", "full": "H時mm分ss秒 z"},
+		months:      cjkMonths,
+		shortMonths: cjkMonths,
+		weekdays:    []string{"日曜日", "月曜日", "火曜日", "水曜日", "木曜日", "金曜日", "土曜日"},
+	},
+	"zh": {
+		date:        map[string]string{"short": "y/M/d", "medium": "y年M月d日", "long": "y年M月d日", "full": "y年M月d日EEEE"},
+		time:        twentyFourHours,
+		months:      cjkMonths,
+		shortMonths: cjkMonths,
+		weekdays:    []string{"星期日", "星期一", "星期二", "星期三", "星期四", "星期五", "星期六"},
+	},
+}
+
+// relativeTimeUnits relative time patterns of locales, `{0}` will be replaced with number, locales without data use data of parent locales
+var relativeTimeUnits = map[string]map[string][4]string{
+	// unit: future one, future other, past one, past other
+	"und": {
+		"second": {"+{0} s", "+{0} s", "-{0} s", "-{0} s"},
+		"minute": {"+{0} min", "+{0} min", "-{0} min", "-{0} min"},
+		"hour":   {"+{0} h", "+{0} h", "-{0} h", "-{0} h"},
+		"day":    {"+{0} d", "+{0} d", "-{0} d", "-{0} d"},
+		"month":  {"+{0} m", "+{0} m", "-{0} m", "-{0} m"},
+		"year":   {"+{0} y", "+{0} y", "-{0} y", "-{0} y"},
+	},
+	"en": {
+		"second": {"in {0} second", "in {0} seconds", "{0} second ago", "{0} seconds ago"},
+		"minute": {"in {0} minute", "in {0} minutes", "{0} minute ago", "{0} minutes ago"},
+		"hour":   {"in {0} hour", "in {0} hours", "{0} hour ago", "{0} hours ago"},
+		"day":    {"in {0} day", "in {0} days", "{0} day ago", "{0} days ago"},
+		"month":  {"in {0} month", "in {0} months", "{0} month ago", "{0} months ago"},
+		"year":   {"in {0} year", "in {0} years", "{0} year ago", "{0} years ago"},
+	},
+	"de": {
+		"second": {"in {0} Sekunde", "in {0} Sekunden", "vor {0} Sekunde", "vor {0} Sekunden"},
+		"minute": {"in {0} Minute", "in {0} Minuten", "vor {0} Minute", "vor {0} Minuten"},
+		"hour":   {"in {0} Stunde", "in {0} Stunden", "vor {0} Stunde", "vor {0} Stunden"},
+		"day":    {"in {0} Tag", "in {0} Tagen", "vor {0} Tag", "vor {0} Tagen"},
+		"month":  {"in {0} Monat", "in {0} Monaten", "vor {0} Monat", "vor {0} Monaten"},
+		"year":   {"in {0} Jahr", "in {0} Jahren", "vor {0} Jahr", "vor {0} Jahren"},
+	},
+	"fr": {
+		"second": {"dans {0} seconde", "dans {0} secondes", "il y a {0} seconde", "il y a {0} secondes"},
+		"minute": {"dans {0} minute", "dans {0} minutes", "il y a {0} minute", "il y a {0} minutes"},
+		"hour":   {"dans {0} heure", "dans {0} heures", "il y a {0} heure", "il y a {0} heures"},
+		"day":    {"dans {0} jour", "dans {0} jours", "il y a {0} jour", "il y a {0} jours"},
+		"month":  {"dans {0} mois", "dans {0} mois", "il y a {0} mois", "il y a {0} mois"},
+		"year":   {"dans {0} an", "dans {0} ans", "il y a {0} an", "il y a {0} ans"},
+	},
+	"es": {
+		"second": {"dentro de {0} segundo", "dentro de {0} segundos", "hace {0} segundo", "hace {0} segundos"},
+		"minute": {"dentro de {0} minuto", "dentro de {0} minutos", "hace {0} minuto", "hace {0} minutos"},
+		"hour":   {"dentro de {0} hora", "dentro de {0} horas", "hace {0} hora", "hace {0} horas"},
+		"day":    {"dentro de {0} día", "dentro de {0} días", "hace {0} día", "hace {0} días"},
+		"month":  {"dentro de {0} mes", "dentro de {0} meses", "hace {0} mes", "hace {0} meses"},
+		"year":   {"dentro de {0} año", "dentro de {0} años", "hace {0} año", "hace {0} años"},
+	},
+	"ja": {
+		"second": {"{0} 秒後", "{0} 秒後", "{0} 秒前", "{0} 秒前"},
+		"minute": {"{0} 分後", "{0} 分後", "{0} 分前", "{0} 分前"},
+		"hour":   {"{0} 時間後", "{0} 時間後", "{0} 時間前", "{0} 時間前"},
+		"day":    {"{0} 日後", "{0} 日後", "{0} 日前", "{0} 日前"},
+		"month":  {"{0} か月後", "{0} か月後", "{0} か月前", "{0} か月前"},
+		"year":   {"{0} 年後", "{0} 年後", "{0} 年前", "{0} 年前"},
+	},
+	"zh": {
+		"second": {"{0}秒钟后", "{0}秒钟后", "{0}秒钟前", "{0}秒钟前"},
+		"minute": {"{0}分钟后", "{0}分钟后", "{0}分钟前", "{0}分钟前"},
+		"hour":   {"{0}小时后", "{0}小时后", "{0}小时前", "{0}小时前"},
+		"day":    {"{0}天后", "{0}天后", "{0}天前", "{0}天前"},
+		"month":  {"{0}个月后", "{0}个月后", "{0}个月前", "{0}个月前"},
+		"year":   {"{0}年后", "{0}年后", "{0}年前", "{0}年前"},
+	},
+}
@@ -0,0 +1,302 @@
+package i18n
+
+import (
+	"bytes"
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"text/template"
+	"text/template/parse"
+
+	"golang.org/x/text/feature/plural"
+	"golang.org/x/text/language"
+	"golang.org/x/text/message"
+)
+
+// TemplateFormatter format translation with golang template, it is compatible with cldr syntax (`p`, `zero`, `one`, `two`, `few`, `many`, `other` and ordered params like `{{$1}}`),
+// and has locale-aware helpers, e.g:
+//
+//	{{ordinal .Rank (one "{{.Rank}}st") (two "{{.Rank}}nd") (few "{{.Rank}}rd") (other "{{.Rank}}th")}}
+//	{{number .Total}}, {{percent .Rate}}, {{currency "EUR" .Price}}, {{unit "km" .Distance}}
+//	{{date "short" .CreatedAt}}, {{time "medium" .CreatedAt}}, {{relative_time .UpdatedAt}}
+//	{{gender .Gender (male "Il") (female "Elle") (other "Iel")}}, {{select .Role (when "admin" "Administrator") (other "User")}}
+type TemplateFormatter struct{}
+
+// Format format translation with golang template
+func (formatter TemplateFormatter) Format(locale, value string, args ...interface{}) (string, error) {
+	tmpl, err := formatter.Compile(locale, value)
+	if err != nil {
+		return value, err
+	}
+	return tmpl.Execute(args...)
+}
+
+var orderedParamRegexp = regexp.MustCompile(`\$(\d+)`)
+
+// Compile parse translation into golang template
+func (TemplateFormatter) Compile(locale, value string) (CompiledTemplate, error) {
+	return compileTemplate(locale, value, true)
+}
+
+// compileTemplate parse translation into golang template, locale-aware helpers are registered if helpers is true, otherwise only cldr functions, `ordinal`, `select` and `gender` are available
+func compileTemplate(locale, value string, helpers bool) (CompiledTemplate, error) {
+	if !strings.Contains(value, "{{") {
+		return staticTemplate(value), nil
+	}
+
+	var (
+		tag          = language.Make(locale)
+		params       []int
+		declarations string
+	)
+
+	// declare ordered params, e.g: `{{$1 := arg 1}}`
+	for _, match := range orderedParamRegexp.FindAllStringSubmatch(value, -1) {
+		if idx, err := strconv.Atoi(match[1]); err == nil {
+			params = append(params, idx)
+		}
+	}
+	sort.Ints(params)
+	for i, idx := range params {
+		if i == 0 || params[i-1] != idx {
+			declarations += fmt.Sprintf("{{$%d := arg %d}}", idx, idx)
+		}
+	}
+
+	var funcs = pluralFormFuncs()
+	if helpers {
+		funcs = templateFuncs(tag, message.NewPrinter(tag))
+	}
+	funcs["arg"] = func(int) interface{} { return nil }
+	funcs["p"] = func(interface{}, ...pluralForm) (string, error) { return "", nil }
+	funcs["ordinal"] = funcs["p"]
+	funcs["select"] = func(interface{}, ...pluralForm) (string, error) { return "", nil }
+	funcs["gender"] = funcs["select"]
+
+	tmpl, err := template.New("").Funcs(funcs).Parse(declarations + value)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := validateSelects(tmpl.Tree.Root); err != nil {
+		return nil, err
+	}
+	return &goTemplate{template: tmpl, locale: locale, tag: tag, helpers: helpers}, nil
+}
+
+type goTemplate struct {
+	template *template.Template
+	locale   string
+	tag      language.Tag
+	helpers  bool
+	// instances clones of template that could be reused by executions, `*templateInstance`
+	instances sync.Pool
+}
+
+// templateInstance clone of template, its functions are bound to arguments of current execution
+type templateInstance struct {
+	template *template.Template
+	args     []interface{}
+}
+
+// instance get an idle instance of template, functions of a new instance are bound once when it is cloned
+func (tmpl *goTemplate) instance() (*templateInstance, error) {
+	if instance, ok := tmpl.instances.Get().(*templateInstance); ok {
+		return instance, nil
+	}
+
+	t, err := tmpl.template.Clone()
+	if err != nil {
+		return nil, err
+	}
+
+	instance := &templateInstance{template: t}
+	t.Funcs(template.FuncMap{
+		"arg": func(idx int) interface{} {
+			if idx > 0 && idx <= len(instance.args) {
+				return instance.args[idx-1]
+			}
+			return nil
+		},
+		"p": func(count interface{}, forms ...pluralForm) (string, error) {
+			return tmpl.plural(plural.Cardinal, count, forms, instance.args)
+		},
+		"ordinal": func(count interface{}, forms ...pluralForm) (string, error) {
+			return tmpl.plural(plural.Ordinal, count, forms, instance.args)
+		},
+		"select": func(value interface{}, cases ...pluralForm) (string, error) {
+			return tmpl.selectCase(value, cases, instance.args)
+		},
+		"gender": func(value interface{}, cases ...pluralForm) (string, error) {
+			return tmpl.selectCase(value, cases, instance.args)
+		},
+	})
+	return instance, nil
+}
+
+// Execute execute template with arguments, the first argument is used as template data
+func (tmpl *goTemplate) Execute(args ...interface{}) (string, error) {
+	instance, err := tmpl.instance()
+	if err != nil {
+		return "", err
+	}
+
+	var (
+		buf  bytes.Buffer
+		data interface{}
+	)
+	if len(args) > 0 {
+		data = args[0]
+	}
+
+	instance.args = args
+	err = instance.template.Execute(&buf, data)
+	instance.args = nil
+	tmpl.instances.Put(instance)
+
+	if err != nil {
+		return "", err
+	}
+	return buf.String(), nil
+}
+
+// pluralForm plural form of cldr syntax, e.g: `(one "{{.Count}} item")`, it is also used as case of select, e.g: `(male "Il")`
+type pluralForm struct {
+	category string
+	value    string
+}
+
+func pluralFormFunc(category string) func(string) pluralForm {
+	return func(value string) pluralForm {
+		return pluralForm{category: category, value: value}
+	}
+}
+
+// nestedTemplates compiled templates of plural forms and select cases, `map[nestedTemplateKey]CompiledTemplate`
+var nestedTemplates sync.Map
+
+type nestedTemplateKey struct {
+	locale  string
+	value   string
+	helpers bool
+}
+
+// plural choose plural form for count with plural rules (cardinal or ordinal), count could be a number, or name of the argument's field
+func (tmpl *goTemplate) plural(rules *plural.Rules, count interface{}, forms []pluralForm, args []interface{}) (string, error) {
+	if name, ok := count.(string); ok {
+		if _, err := strconv.ParseFloat(name, 64); err != nil {
+			if count, ok = icuArgument(name, args); !ok {
+				return "", fmt.Errorf("missing argument %v", name)
+			}
+		}
+	}
+
+	_, operands, err := pluralOperands(count)
+	if err != nil {
+		return "", err
+	}
+
+	return tmpl.render(pluralCategory(rules, tmpl.tag, operands), forms, args)
+}
+
+// selectCase select case by value, e.g: `{{select .Gender (male "Il") (female "Elle") (other "Iel")}}`
+func (tmpl *goTemplate) selectCase(value interface{}, cases []pluralForm, args []interface{}) (string, error) {
+	return tmpl.render(fmt.Sprint(value), cases, args)
+}
+
+// render render form of category, form `other` will be used if not found
+func (tmpl *goTemplate) render(category string, forms []pluralForm, args []interface{}) (string, error) {
+	var (
+		value string
+		found bool
+	)
+
+	for _, form := range forms {
+		if form.category == category {
+			value, found = form.value, true
+			break
+		}
+		if form.category == "other" {
+			value, found = form.value, true
+		}
+	}
+
+	if !found {
+		return "", fmt.Errorf("missing form %v or other", category)
+	}
+
+	key := nestedTemplateKey{locale: tmpl.locale, value: value, helpers: tmpl.helpers}
+	compiled, ok := nestedTemplates.Load(key)
+	if !ok {
+		c, err := compileTemplate(tmpl.locale, value, tmpl.helpers)
+		if err != nil {
+			return "", err
+		}
+		compiled, _ = nestedTemplates.LoadOrStore(key, c)
+	}
+	return compiled.(CompiledTemplate).Execute(args...)
+}
+
+// validateSelects validate `select` and `gender` in template have `other` case
+func validateSelects(node parse.Node) error {
+	switch n := node.(type) {
+	case *parse.ListNode:
+		if n != nil {
+			for _, child := range n.Nodes {
+				if err := validateSelects(child); err != nil {
+					return err
+				}
+			}
+		}
+	case *parse.ActionNode:
+		return validateSelects(n.Pipe)
+	case *parse.IfNode:
+		return validateBranch(&n.BranchNode)
+	case *parse.RangeNode:
+		return validateBranch(&n.BranchNode)
+	case *parse.WithNode:
+		return validateBranch(&n.BranchNode)
+	case *parse.PipeNode:
+		if n != nil {
+			for _, cmd := range n.Cmds {
+				if err := validateSelects(cmd); err != nil {
+					return err
+				}
+			}
+		}
+	case *parse.CommandNode:
+		if ident, ok := n.Args[0].(*parse.IdentifierNode); ok && (ident.Ident == "select" || ident.Ident == "gender") {
+			var hasOther bool
+			for _, arg := range n.Args[1:] {
+				if pipe, ok := arg.(*parse.PipeNode); ok && len(pipe.Cmds) > 0 {
+					if ident, ok := pipe.Cmds[0].Args[0].(*parse.IdentifierNode); ok && ident.Ident == "other" {
+						hasOther = true
+					}
+				}
+			}
+
+			if !hasOther {
+				return fmt.Errorf("%v should have `other` case: %v", ident.Ident, n)
+			}
+		}
+
+		for _, arg := range n.Args {
+			if err := validateSelects(arg); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func validateBranch(node *parse.BranchNode) error {
+	for _, n := range []parse.Node{node.Pipe, node.List, node.ElseList} {
+		if err := validateSelects(n); err != nil {
+			return err
+		}
+	}
+	return nil
+}
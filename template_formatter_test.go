@@ -0,0 +1,90 @@
+package i18n
+
+import (
+	"testing"
+	"time"
+)
+
+type templateFormatterTestCase struct {
+	Locale string
+	Value  string
+	Args   []interface{}
+	Expect string
+}
+
+func TestTemplateFormatter(t *testing.T) {
+	date := time.Date(2017, 3, 5, 14, 8, 0, 0, time.UTC)
+
+	testCases := []templateFormatterTestCase{
+		{Locale: "en-US", Value: "Hello {{.Name}}", Args: []interface{}{map[string]string{"Name": "Jinzhu"}}, Expect: "Hello Jinzhu"},
+		{Locale: "en-US", Value: "{{$1}} {{$2}} {{$1}}", Args: []interface{}{"string1", "string2"}, Expect: "string1 string2 string1"},
+		{Locale: "en-US", Value: `{{p "Count" (one "{{.Count}} item") (other "{{.Count}} items")}}`, Args: []interface{}{map[string]int{"Count": 1}}, Expect: "1 item"},
+		{Locale: "en-US", Value: `{{p "Count" (one "{{.Count}} item") (other "{{.Count}} items")}}`, Args: []interface{}{map[string]int{"Count": 2}}, Expect: "2 items"},
+		{Locale: "pl", Value: `{{p $1 (one "plik") (few "pliki") (many "plików") (other "pliku")}}`, Args: []interface{}{5}, Expect: "plików"},
+		{Locale: "en-US", Value: "{{number .}}", Args: []interface{}{1234567.891}, Expect: "1,234,567.891"},
+		{Locale: "de-DE", Value: "{{number .}}", Args: []interface{}{1234567.891}, Expect: "1.234.567,891"},
+		{Locale: "fr-FR", Value: "{{percent .}}", Args: []interface{}{0.25}, Expect: "25\u00a0%"},
+		{Locale: "en-US", Value: `{{currency "EUR" .}}`, Args: []interface{}{1234.5}, Expect: "€1,234.50"},
+		{Locale: "de-DE", Value: `{{currency "EUR" .}}`, Args: []interface{}{1234.5}, Expect: "1.234,50\u00a0€"},
+		{Locale: "ja-JP", Value: `{{currency "JPY" .}}`, Args: []interface{}{1234}, Expect: "￥1,234"},
+		{Locale: "de-DE", Value: `{{unit "km" .}}`, Args: []interface{}{1234.5}, Expect: "1.234,5\u00a0km"},
+		{Locale: "en-US", Value: `{{date "short" .}}`, Args: []interface{}{date}, Expect: "3/5/17"},
+		{Locale: "en-GB", Value: `{{date "long" .}}`, Args: []interface{}{date}, Expect: "5 March 2017"},
+		{Locale: "de-DE", Value: `{{date "full" .}}`, Args: []interface{}{date}, Expect: "Sonntag, 5. März 2017"},
+		{Locale: "es-ES", Value: `{{date "long" .}}`, Args: []interface{}{date}, Expect: "5 de marzo de 2017"},
+		{Locale: "zh-CN", Value: `{{date "long" .}}`, Args: []interface{}{date}, Expect: "2017年3月5日"},
+		{Locale: "en-US", Value: `{{time "short" .}}`, Args: []interface{}{date}, Expect: "2:08 PM"},
+		{Locale: "fr-FR", Value: `{{time "short" .}}`, Args: []interface{}{date}, Expect: "14:08"},
+		{Locale: "en-US", Value: `{{relative_time .}}`, Args: []interface{}{-3 * 24 * time.Hour}, Expect: "3 days ago"},
+		{Locale: "de-DE", Value: `{{relative_time .}}`, Args: []interface{}{time.Hour}, Expect: "in 1 Stunde"},
+		{Locale: "fr-FR", Value: `{{relative_time .}}`, Args: []interface{}{-2 * time.Minute}, Expect: "il y a 2 minutes"},
+		{Locale: "de-AT", Value: `{{date "long" .}}`, Args: []interface{}{date}, Expect: "5. März 2017"},
+		{Locale: "xx", Value: `{{date "short" .}} {{time "short" .}}`, Args: []interface{}{date}, Expect: "2017-03-05 14:08"},
+		{Locale: "xx", Value: `{{relative_time .}}`, Args: []interface{}{-3 * 24 * time.Hour}, Expect: "-3 d"},
+		{Locale: "fr-FR", Value: `{{gender .Gender (male "Il") (female "Elle") (other "Iel")}} est arrivé`, Args: []interface{}{map[string]string{"Gender": "female"}}, Expect: "Elle est arrivé"},
+		{Locale: "fr-FR", Value: `{{gender .Gender (male "Il") (female "Elle") (other "Iel")}}`, Args: []interface{}{map[string]string{"Gender": "unknown"}}, Expect: "Iel"},
+		{Locale: "de-DE", Value: `{{select .Role (when "admin" "Hallo {{.Name}}, Administrator") (other "Hallo {{.Name}}")}}`, Args: []interface{}{map[string]string{"Role": "admin", "Name": "Jinzhu"}}, Expect: "Hallo Jinzhu, Administrator"},
+		{Locale: "pl", Value: `{{select $1 (when 1 "jeden") (other "inny")}}`, Args: []interface{}{1}, Expect: "jeden"},
+	}
+
+	for i, testCase := range testCases {
+		if value, err := (TemplateFormatter{}).Format(testCase.Locale, testCase.Value, testCase.Args...); err != nil || value != testCase.Expect {
+			t.Errorf("#%d: expect %q, but got %q (%v)", i+1, testCase.Expect, value, err)
+		}
+	}
+}
+
+func TestTemplateFormatterSelectWithoutOther(t *testing.T) {
+	for _, value := range []string{
+		`{{select .Gender (male "Il") (female "Elle")}}`,
+		`{{if .Show}}{{gender .Gender (male "Il")}}{{end}}`,
+	} {
+		if _, err := (TemplateFormatter{}).Compile("fr-FR", value); err == nil {
+			t.Errorf("should return error for select without other case: %v", value)
+		}
+	}
+}
+
+func TestTemplateFormatterOrdinal(t *testing.T) {
+	values := map[string]string{
+		"en": `{{ordinal . (one "{{.}}st") (two "{{.}}nd") (few "{{.}}rd") (other "{{.}}th")}}`,
+		"fr": `{{ordinal . (one "{{.}}er") (other "{{.}}e")}}`,
+		"it": `{{ordinal . (many "l'{{.}}º") (other "il {{.}}º")}}`,
+		"cy": `{{ordinal . (zero "{{.}}fed") (one "{{.}}af") (two "{{.}}il") (few "{{.}}ydd") (many "{{.}}ed") (other "{{.}}fed")}}`,
+	}
+
+	testCases := map[string]map[int]string{
+		"en": {1: "1st", 2: "2nd", 3: "3rd", 4: "4th", 11: "11th", 12: "12th", 13: "13th", 21: "21st", 22: "22nd", 23: "23rd", 101: "101st"},
+		"fr": {1: "1er", 2: "2e", 3: "3e", 21: "21e"},
+		"it": {1: "il 1º", 8: "l'8º", 11: "l'11º", 80: "l'80º", 800: "l'800º", 81: "il 81º"},
+		"cy": {0: "0fed", 1: "1af", 2: "2il", 3: "3ydd", 4: "4ydd", 5: "5ed", 6: "6ed", 7: "7fed", 10: "10fed", 20: "20fed"},
+	}
+
+	for locale, cases := range testCases {
+		for number, expect := range cases {
+			if value, err := (TemplateFormatter{}).Format(locale, values[locale], number); err != nil || value != expect {
+				t.Errorf("ordinal of %v for %v: expect %q, but got %q (%v)", number, locale, expect, value, err)
+			}
+		}
+	}
+}
@@ -0,0 +1,267 @@
+package i18n
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"text/template"
+	"time"
+
+	"golang.org/x/text/currency"
+	"golang.org/x/text/feature/plural"
+	"golang.org/x/text/language"
+	"golang.org/x/text/message"
+	"golang.org/x/text/number"
+)
+
+// pluralFormFuncs functions of cldr plural forms and select cases, they are available in both CLDRFormatter and TemplateFormatter
+func pluralFormFuncs() template.FuncMap {
+	return template.FuncMap{
+		"zero":  pluralFormFunc("zero"),
+		"one":   pluralFormFunc("one"),
+		"two":   pluralFormFunc("two"),
+		"few":   pluralFormFunc("few"),
+		"many":  pluralFormFunc("many"),
+		"other": pluralFormFunc("other"),
+		// select cases, e.g: `{{select .Gender (male "Il") (female "Elle") (other "Iel")}}`, `{{select .Role (when "admin" "Administrator") (other "User")}}`
+		"male":   pluralFormFunc("male"),
+		"female": pluralFormFunc("female"),
+		"when": func(value interface{}, message string) pluralForm {
+			return pluralForm{category: fmt.Sprint(value), value: message}
+		},
+	}
+}
+
+// templateFuncs functions for translation templates of locale
+func templateFuncs(tag language.Tag, printer *message.Printer) template.FuncMap {
+	funcs := template.FuncMap{
+		"number": func(value interface{}) string {
+			return printer.Sprint(number.Decimal(value))
+		},
+		"percent": func(value interface{}) string {
+			return printer.Sprint(number.Percent(value))
+		},
+		"currency": func(code string, value interface{}) (string, error) {
+			return formatCurrency(tag, printer, code, value)
+		},
+		"unit": func(unit string, value interface{}) string {
+			return printer.Sprint(number.Decimal(value)) + "\u00a0" + unit
+		},
+		"date": func(style string, value time.Time) string {
+			return formatDateTime(tag, getDateTimeFormat(tag).date, style, value)
+		},
+		"time": func(style string, value time.Time) string {
+			return formatDateTime(tag, getDateTimeFormat(tag).time, style, value)
+		},
+		"relative_time": func(value interface{}) (string, error) {
+			return formatRelativeTime(tag, printer, value)
+		},
+	}
+
+	for name, fc := range pluralFormFuncs() {
+		funcs[name] = fc
+	}
+	return funcs
+}
+
+func formatCurrency(tag language.Tag, printer *message.Printer, code string, value interface{}) (string, error) {
+	unit, err := currency.ParseISO(code)
+	if err != nil {
+		return "", err
+	}
+
+	var (
+		scale, _ = currency.Standard.Rounding(unit)
+		symbol   = printer.Sprint(currency.Symbol(unit))
+		amount   = printer.Sprint(number.Decimal(value, number.Scale(scale)))
+	)
+
+	var suffix bool
+	for _, locale := range cldrLocales(tag) {
+		if value, ok := currencySymbolSuffixes[locale]; ok {
+			suffix = value
+			break
+		}
+	}
+
+	if suffix {
+		return amount + "\u00a0" + symbol, nil
+	}
+	return symbol + amount, nil
+}
+
+// dateTimeFormat date, time patterns and names of locale, data comes from CLDR, see tables.go
+type dateTimeFormat struct {
+	date        map[string]string
+	time        map[string]string
+	months      []string
+	shortMonths []string
+	weekdays    []string
+	am, pm      string
+}
+
+// cldrLocales return locale and its parent locales in CLDR, e.g: `en-AU` => `en-001` => `en` => `und`
+func cldrLocales(tag language.Tag) (locales []string) {
+	for {
+		locales = append(locales, tag.String())
+		if tag.IsRoot() {
+			return locales
+		}
+		tag = tag.Parent()
+	}
+}
+
+// getDateTimeFormat get date time format for locale, e.g: `en-GB`, then `en-001`, `en`, data of CLDR root locale is used if not found
+func getDateTimeFormat(tag language.Tag) *dateTimeFormat {
+	for _, locale := range cldrLocales(tag) {
+		if format, ok := dateTimeFormats[locale]; ok {
+			return format
+		}
+	}
+	return dateTimeFormats["und"]
+}
+
+// formatDateTime format time with CLDR date time pattern of style `short`, `medium`, `long` or `full`
+func formatDateTime(tag language.Tag, patterns map[string]string, style string, value time.Time) string {
+	pattern, ok := patterns[style]
+	if !ok {
+		pattern = patterns["medium"]
+	}
+
+	var (
+		format  = getDateTimeFormat(tag)
+		builder strings.Builder
+		runes   = []rune(pattern)
+	)
+
+	for i := 0; i < len(runes); {
+		r := runes[i]
+
+		// quoted literal, e.g: `'de'`
+		if r == '\'' {
+			end := i + 1
+			for end < len(runes) && runes[end] != '\'' {
+				end++
+			}
+			builder.WriteString(string(runes[i+1 : end]))
+			i = end + 1
+			continue
+		}
+
+		if !(r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z') {
+			builder.WriteRune(r)
+			i++
+			continue
+		}
+
+		count := 1
+		for i+count < len(runes) && runes[i+count] == r {
+			count++
+		}
+		i += count
+
+		switch r {
+		case 'y':
+			if count == 2 {
+				builder.WriteString(fmt.Sprintf("%02d", value.Year()%100))
+			} else {
+				builder.WriteString(strconv.Itoa(value.Year()))
+			}
+		case 'M':
+			switch {
+			case count >= 4:
+				builder.WriteString(format.months[value.Month()-1])
+			case count == 3:
+				builder.WriteString(format.shortMonths[value.Month()-1])
+			default:
+				builder.WriteString(padNumber(int(value.Month()), count))
+			}
+		case 'd':
+			builder.WriteString(padNumber(value.Day(), count))
+		case 'E':
+			builder.WriteString(format.weekdays[value.Weekday()])
+		case 'H':
+			builder.WriteString(padNumber(value.Hour(), count))
+		case 'h':
+			hour := value.Hour() % 12
+			if hour == 0 {
+				hour = 12
+			}
+			builder.WriteString(padNumber(hour, count))
+		case 'm':
+			builder.WriteString(padNumber(value.Minute(), count))
+		case 's':
+			builder.WriteString(padNumber(value.Second(), count))
+		case 'a':
+			if value.Hour() < 12 {
+				builder.WriteString(format.am)
+			} else {
+				builder.WriteString(format.pm)
+			}
+		case 'z':
+			zone, _ := value.Zone()
+			builder.WriteString(zone)
+		}
+	}
+	return builder.String()
+}
+
+func padNumber(n, width int) string {
+	return fmt.Sprintf("%0*d", width, n)
+}
+
+// formatRelativeTime format time or duration relative to now, e.g: `in 3 days`, `2 hours ago`
+func formatRelativeTime(tag language.Tag, printer *message.Printer, value interface{}) (string, error) {
+	var duration time.Duration
+	switch v := value.(type) {
+	case time.Time:
+		duration = time.Until(v)
+	case time.Duration:
+		duration = v
+	default:
+		return "", fmt.Errorf("relative_time: %v is not a time or duration", value)
+	}
+
+	var (
+		seconds = math.Abs(duration.Seconds())
+		unit    = "second"
+		count   = seconds
+	)
+
+	switch {
+	case seconds >= 365*24*3600:
+		unit, count = "year", seconds/(365*24*3600)
+	case seconds >= 30*24*3600:
+		unit, count = "month", seconds/(30*24*3600)
+	case seconds >= 24*3600:
+		unit, count = "day", seconds/(24*3600)
+	case seconds >= 3600:
+		unit, count = "hour", seconds/3600
+	case seconds >= 60:
+		unit, count = "minute", seconds/60
+	}
+
+	var units = relativeTimeUnits["und"]
+	for _, locale := range cldrLocales(tag) {
+		if values, ok := relativeTimeUnits[locale]; ok {
+			units = values
+			break
+		}
+	}
+
+	var (
+		n        = int(math.Round(count))
+		patterns = units[unit]
+		idx      = 1
+	)
+
+	if _, operands, err := pluralOperands(n); err == nil && pluralCategory(plural.Cardinal, tag, operands) == "one" {
+		idx = 0
+	}
+
+	if duration < 0 {
+		idx += 2
+	}
+	return strings.Replace(patterns[idx], "{0}", printer.Sprint(number.Decimal(n)), 1), nil
+}